@@ -2,29 +2,116 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/user"
 	"runtime"
 
 	"kisumu/cmd/repl"
+	"kisumu/pkg/interpreter"
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/object"
+	"kisumu/pkg/parser"
 )
 
+const VERSION = "0.1.0"
+
+const usage = `Kisumu is a tool for running Kisumu (.ksm) programs.
+
+Usage:
+
+	kisumu <command> [arguments]
+
+The commands are:
+
+	run <file.ksm> [args...]   run a Kisumu script ("-" reads it from stdin)
+	repl                       start an interactive session
+	version                    print the Kisumu version
+	help                       show this message
+
+Running kisumu with no command starts the REPL.
+`
+
 func main() {
-	// bytes, _ := os.ReadFile("/examples/example.ksm")
-	// source := string(bytes)
+	if len(os.Args) < 2 {
+		startRepl()
+		return
+	}
 
-	// fmt.Printf("Code: %s", source)
+	switch cmd := os.Args[1]; cmd {
+	case "run":
+		os.Exit(runFile(os.Args[2:], os.Stderr))
+	case "repl":
+		startRepl()
+	case "version":
+		fmt.Printf("Kisumu version: %s %s/%s\n", VERSION, runtime.GOOS, runtime.GOARCH)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "kisumu: unknown command %q\n\n", cmd)
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
 
+func startRepl() {
 	user, err := user.Current()
 	if err != nil {
 		fmt.Printf("Error getting user: %v", err)
 		os.Exit(1)
 	}
 	fmt.Printf("%s/%s\n", user.Username, runtime.GOOS)
-	fmt.Println("Kisumu version: 0.1.0")
+	fmt.Printf("Kisumu version: %s\n", VERSION)
 
 	fmt.Println("\nWelcome to the Kisumu programming language!")
-	fmt.Println("Type 'help' for a list of available commands.")
+	fmt.Println("Type ':help' for a list of available commands.")
 	fmt.Printf("Feel free to type in commands, and let's start coding!\n\n")
 	repl.Start(os.Stdin, os.Stdout)
 }
+
+// runFile executes the script named by args[0], or the one on standard input
+// if args[0] is "-". The remaining arguments are exposed to the script as the
+// `args` array. It returns the process exit code.
+func runFile(args []string, stderr io.Writer) int {
+	if len(args) < 1 {
+		fmt.Fprintln(stderr, "usage: kisumu run <file.ksm> [args...]")
+		return 2
+	}
+	path := args[0]
+
+	source := io.Reader(os.Stdin)
+	if path == "-" {
+		path = "<stdin>"
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "kisumu: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		source = f
+	}
+
+	l := lexer.TokenizeReader(path, source)
+	p := parser.NewParser(l)
+	program := p.ParseProgram()
+	if errors := p.Errors(); len(errors) != 0 {
+		for _, msg := range errors {
+			fmt.Fprintln(stderr, msg)
+		}
+		return 1
+	}
+
+	env := interpreter.NewEnvironment()
+	scriptArgs := make([]object.Object, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		scriptArgs = append(scriptArgs, &object.String{Value: arg})
+	}
+	env.Set("args", &object.Array{Elements: scriptArgs})
+
+	if result := interpreter.Eval(program, env); result != nil && result.Type() == object.ERROR_OBJ {
+		fmt.Fprintf(stderr, "%s: runtime error: %s\n", path, result.(*object.Error).Message)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"kisumu/pkg/interpreter"
+)
+
+// writeScript writes source to a temporary .ksm file and returns its path.
+func writeScript(t *testing.T, source string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "script.ksm")
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunFile(t *testing.T) {
+	var stdout bytes.Buffer
+	interpreter.Output = &stdout
+	defer func() { interpreter.Output = os.Stdout }()
+
+	path := writeScript(t, "let x = 2\nprintln(x * 21)\nprintln(args)\n")
+	var stderr bytes.Buffer
+	if code := runFile([]string{path, "a", "b"}, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr %q", code, stderr.String())
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("unexpected stderr %q", stderr.String())
+	}
+	if expected := "42\n[\"a\", \"b\"]\n"; stdout.String() != expected {
+		t.Errorf("expected output %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestRunFileErrors(t *testing.T) {
+	parseError := writeScript(t, "let x = 1\nlet = 5\n")
+	runtimeError := writeScript(t, "let x = 1\nx + true\n")
+	missing := filepath.Join(t.TempDir(), "missing.ksm")
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedCode   int
+		expectedStderr string
+	}{
+		{"usage", nil, 2, "usage: kisumu run <file.ksm> [args...]\n"},
+		{"missing file", []string{missing}, 1, "kisumu: open " + missing + ": no such file or directory\n"},
+		{"parser error", []string{parseError}, 1, parseError + ":2:5: expected next token to be IDENTIFIER, got ASSIGNMENT instead\n"},
+		{"runtime error", []string{runtimeError}, 1, runtimeError + ": runtime error: type mismatch: INTEGER + BOOLEAN\n"},
+	}
+
+	for _, tt := range tests {
+		var stderr bytes.Buffer
+		code := runFile(tt.args, &stderr)
+		if code != tt.expectedCode {
+			t.Errorf("%s: expected exit code %d, got %d", tt.name, tt.expectedCode, code)
+		}
+		if got := stderr.String(); got != tt.expectedStderr {
+			t.Errorf("%s: expected stderr %q, got %q", tt.name, tt.expectedStderr, got)
+		}
+	}
+}
+
+func TestRunFileReportsEveryParserError(t *testing.T) {
+	path := writeScript(t, "let = 1\nlet y = )\n")
+	var stderr bytes.Buffer
+	if code := runFile([]string{path}, &stderr); code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	lines := strings.Split(strings.TrimSuffix(stderr.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 errors, got %q", stderr.String())
+	}
+	for i, line := range lines {
+		prefix := fmt.Sprintf("%s:%d:", path, i+1)
+		if !strings.HasPrefix(line, prefix) {
+			t.Errorf("error %d: expected prefix %q, got %q", i, prefix, line)
+		}
+	}
+}
@@ -4,19 +4,32 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"strings"
 
+	"kisumu/pkg/interpreter"
 	"kisumu/pkg/lexer"
+	"kisumu/pkg/parser"
 )
 
 const PROMPT = "kisumu $"
 
+const HELP = `Commands:
+  :tokens   print the tokens of each line instead of evaluating it
+  :eval     evaluate each line (the default)
+  :help     show this message
+`
+
 // Start is a read-eval-print loop (REPL) for the Kisumu programming language.
-// It continuously reads input from the provided reader, tokenizes it,
-// and writes the token type and literal to the provided writer.
+// It continuously reads lines from the provided reader, parses and evaluates
+// them against an environment that persists between lines, and writes the
+// result to the provided writer. Entering :tokens switches to printing the
+// tokens of each line instead, and :eval switches back.
 func Start(in io.Reader, out io.Writer) {
 
 	scanner := bufio.NewScanner(in)
 	writer := bufio.NewWriter(out)
+	env := interpreter.NewEnvironment()
+	tokensMode := false
 
 	for {
 		fmt.Fprint(writer, PROMPT)
@@ -32,44 +45,93 @@ func Start(in io.Reader, out io.Writer) {
 		}
 
 		line := scanner.Text()
-		Lexer := lexer.Tokenize(line)
 
-		for {
-			tok := Lexer.GetNextToken()
-			if tok.Type == lexer.EOF {
-				break
-			}
-			if tok.Type == lexer.ILLEGAL {
-				fmt.Fprintf(writer, "Illegal token: %s\n", tok.Literal)
-			} else {
-				fmt.Fprintf(writer, "Token: %s (%s)\n", tok.Type, tok.Literal)
-				// "Token: %s (%s)\n", tok.Type, tok.Literal
-			}
+		switch strings.TrimSpace(line) {
+		case "":
+			continue
+		case ":tokens":
+			tokensMode = true
+			continue
+		case ":eval":
+			tokensMode = false
+			continue
+		case ":help", "help":
+			fmt.Fprint(writer, HELP)
+			writer.Flush()
+			continue
+		}
+
+		if tokensMode {
+			printTokens(writer, line)
+		} else {
+			evalLine(writer, line, env)
 		}
 		writer.Flush() // Ensure output is written
 	}
 }
 
-type REPL struct {
-	prompt string
-	lexer  *lexer.Lexer
-	// ksm    *lexer
-	// lexer *lexer
-	// env    *Environment
-	// runner *runner
-	input  *bufio.Reader
-	output *bufio.Writer
+func printTokens(writer io.Writer, line string) {
+	Lexer := lexer.Tokenize(line)
+
+	for {
+		tok := Lexer.GetNextToken()
+		if tok.Type == lexer.EOF {
+			break
+		}
+		literal := tok.Literal
+		if tok.Type == lexer.SEMI_COLON && literal == "\n" {
+			literal = "inserted" // ended by the end of the line, not a ;
+		}
+		fmt.Fprintf(writer, "Token: %s (%s)\n", tok.Type, literal)
+	}
+	printDiagnostics(writer, line, Lexer.Errors())
+}
+
+func evalLine(writer io.Writer, line string, env *interpreter.Environment) {
+	l := lexer.Tokenize(line)
+	p := parser.NewParser(l)
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		// The lexer's diagnostics come first in the parser's errors; they
+		// are shown with a caret under the offending character instead.
+		diagnostics := l.Errors()
+		printDiagnostics(writer, line, diagnostics)
+		if errors := p.Errors()[len(diagnostics):]; len(errors) != 0 {
+			printParserErrors(writer, errors)
+		}
+		return
+	}
+
+	evaluated := interpreter.Eval(program, env)
+	if evaluated != nil {
+		fmt.Fprintln(writer, evaluated.Inspect())
+	}
+}
+
+// printDiagnostics prints each diagnostic followed by the line it occurred in
+// and a caret under the column it points at.
+func printDiagnostics(writer io.Writer, line string, diagnostics []lexer.Diagnostic) {
+	for _, d := range diagnostics {
+		fmt.Fprintf(writer, "error: %s\n", d.Error())
+		if d.Pos.Line != 1 || d.Pos.Column < 1 || d.Pos.Column > len(line)+1 {
+			continue
+		}
+		fmt.Fprintf(writer, "\t%s\n\t", line)
+		// Columns count bytes; keep tabs so the caret lines up with the source.
+		for _, ch := range line[:d.Pos.Column-1] {
+			if ch == '\t' {
+				fmt.Fprint(writer, "\t")
+			} else {
+				fmt.Fprint(writer, " ")
+			}
+		}
+		fmt.Fprintln(writer, "^")
+	}
 }
 
-func NewREPL(prompt string, lexer *lexer.Lexer, input *bufio.Reader, output *bufio.Writer) *REPL {
-	return &REPL{
-		prompt: prompt,
-		lexer:  lexer,
-		// ksm:    ksm,
-		// lexer: lexer,
-		// env:    env,
-		// runner: runner,
-		input:  input,
-		output: output,
+func printParserErrors(writer io.Writer, errors []string) {
+	fmt.Fprintln(writer, "parser errors:")
+	for _, msg := range errors {
+		fmt.Fprintf(writer, "\t%s\n", msg)
 	}
 }
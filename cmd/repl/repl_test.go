@@ -8,25 +8,58 @@ import (
 
 // TestStart tests the Start function of the REPL.
 func TestStart(t *testing.T) {
-	input := "let x = 5;\nlet y = 10;\nlet z = x + y;\n"
+	input := "let x = 5;\nlet y = 10;\nlet z = x + y;\nz * 2;\n"
 	expectedOutput := []string{
-		"kisumu $Token: LET (let)",
+		"kisumu $kisumu $kisumu $kisumu $30",
+		"kisumu $",
+	}
+
+	in := strings.NewReader(input)
+	var out bytes.Buffer
+	Start(in, &out)
+
+	outputLines := strings.Split(out.String(), "\n")
+
+	if len(outputLines) != len(expectedOutput) {
+		t.Fatalf("Expected %d lines of output, got %d: %q", len(expectedOutput), len(outputLines), out.String())
+	}
+	for i, expected := range expectedOutput {
+		if outputLines[i] != expected {
+			t.Errorf("Expected output %q, but got %q", expected, outputLines[i])
+		}
+	}
+}
+
+func TestStartReportsErrors(t *testing.T) {
+	input := "let = 5;\nfoo;\n"
+
+	in := strings.NewReader(input)
+	var out bytes.Buffer
+	Start(in, &out)
+
+	output := out.String()
+	for _, expected := range []string{
+		"parser errors:\n\t1:5: expected next token to be IDENTIFIER, got ASSIGNMENT instead\n",
+		"ERROR: identifier not found: foo\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got %q", expected, output)
+		}
+	}
+}
+
+func TestStartTokensMode(t *testing.T) {
+	input := ":tokens\nlet x = 5;\nx\n:eval\nlet y = 10;\ny;\n"
+	expectedOutput := []string{
+		"kisumu $kisumu $Token: LET (let)",
 		"Token: IDENTIFIER (x)",
 		"Token: ASSIGNMENT (=)",
 		"Token: INT (5)",
 		"Token: SEMI_COLON (;)",
-		"kisumu $Token: LET (let)",
-		"Token: IDENTIFIER (y)",
-		"Token: ASSIGNMENT (=)",
-		"Token: INT (10)",
-		"Token: SEMI_COLON (;)",
-		"kisumu $Token: LET (let)",
-		"Token: IDENTIFIER (z)",
-		"Token: ASSIGNMENT (=)",
-		"Token: IDENTIFIER (x)",
-		"Token: PLUS (+)",
-		"Token: IDENTIFIER (y)",
-		"Token: SEMI_COLON (;)",
+		"kisumu $Token: IDENTIFIER (x)",
+		"Token: SEMI_COLON (inserted)",
+		"kisumu $kisumu $kisumu $10",
+		"kisumu $",
 	}
 
 	in := strings.NewReader(input)
@@ -35,9 +68,31 @@ func TestStart(t *testing.T) {
 
 	outputLines := strings.Split(out.String(), "\n")
 
+	if len(outputLines) != len(expectedOutput) {
+		t.Fatalf("Expected %d lines of output, got %d: %q", len(expectedOutput), len(outputLines), out.String())
+	}
 	for i, expected := range expectedOutput {
-		if i < len(outputLines) && outputLines[i] != expected {
+		if outputLines[i] != expected {
 			t.Errorf("Expected output %q, but got %q", expected, outputLines[i])
 		}
 	}
 }
+
+func TestStartShowsLexerDiagnostics(t *testing.T) {
+	input := "let x = 1 # 2;\n:tokens\n\ta && b ~ c;\n"
+
+	in := strings.NewReader(input)
+	var out bytes.Buffer
+	Start(in, &out)
+
+	output := out.String()
+	for _, expected := range []string{
+		"error: 1:11: unexpected character '#'\n\tlet x = 1 # 2;\n\t          ^\n",
+		"Token: AND (&&)\n",
+		"error: 1:9: unexpected character '~'\n\t\ta && b ~ c;\n\t\t       ^\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got %q", expected, output)
+		}
+	}
+}
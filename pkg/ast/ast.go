@@ -2,12 +2,12 @@ package ast
 
 import (
 	"bytes"
+	"strconv"
+	"strings"
 
 	"kisumu/pkg/lexer"
 )
 
-
-
 type PrefixExpression struct {
 	Token    lexer.Token
 	Operator string
@@ -16,17 +16,21 @@ type PrefixExpression struct {
 
 func (pe *PrefixExpression) expressionNode()      {}
 func (pe *PrefixExpression) TokenLiteral() string { return pe.Token.Literal }
+func (pe *PrefixExpression) Pos() lexer.Position  { return pe.Token.Start }
 func (pe *PrefixExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
 	out.WriteString(pe.Operator)
+	if pe.Token.Type == lexer.TYPEOF {
+		out.WriteString(" ")
+	}
 	out.WriteString(pe.Right.String())
 	out.WriteString(")")
 	return out.String()
 }
 
 type InfixExpression struct {
-	Token    lexer.TokenType // the token.Token representing the operator
+	Token    lexer.Token // the token.Token representing the operator
 	Left     Expression
 	Operator string
 	Right    Expression
@@ -35,13 +39,17 @@ type InfixExpression struct {
 func (oe *InfixExpression) expressionNode() {}
 
 func (oe *InfixExpression) TokenLiteral() string {
-	return oe.Right.TokenLiteral()
+	return oe.Token.Literal
+}
+
+func (oe *InfixExpression) Pos() lexer.Position {
+	return oe.Left.Pos()
 }
 
 func (oe *InfixExpression) String() string {
 	var out bytes.Buffer
 
-	out.WriteString("{")
+	out.WriteString("(")
 	out.WriteString(oe.Left.String())
 	out.WriteString(" " + oe.Operator + " ")
 	out.WriteString(oe.Right.String())
@@ -65,6 +73,86 @@ func (il *IntegerLiteral) String() string {
 	return il.Token.Literal
 }
 
+func (il *IntegerLiteral) Pos() lexer.Position {
+	return il.Token.Start
+}
+
+type FloatLiteral struct {
+	Token lexer.Token // the token.Token representing the floating-point value
+	Value float64
+}
+
+func (fl *FloatLiteral) expressionNode() {}
+
+func (fl *FloatLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+
+func (fl *FloatLiteral) String() string {
+	return fl.Token.Literal
+}
+
+func (fl *FloatLiteral) Pos() lexer.Position {
+	return fl.Token.Start
+}
+
+type ImaginaryLiteral struct {
+	Token lexer.Token // the token.Token representing the imaginary value, e.g. 2.5i
+	Value complex128
+}
+
+func (il *ImaginaryLiteral) expressionNode() {}
+
+func (il *ImaginaryLiteral) TokenLiteral() string {
+	return il.Token.Literal
+}
+
+func (il *ImaginaryLiteral) String() string {
+	return il.Token.Literal
+}
+
+func (il *ImaginaryLiteral) Pos() lexer.Position {
+	return il.Token.Start
+}
+
+type RuneLiteral struct {
+	Token lexer.Token // the token.Token holding the decoded character
+	Value rune
+}
+
+func (rl *RuneLiteral) expressionNode() {}
+
+func (rl *RuneLiteral) TokenLiteral() string {
+	return rl.Token.Literal
+}
+
+func (rl *RuneLiteral) String() string {
+	return strconv.QuoteRune(rl.Value)
+}
+
+func (rl *RuneLiteral) Pos() lexer.Position {
+	return rl.Token.Start
+}
+
+type StringLiteral struct {
+	Token lexer.Token // the token.Token holding the decoded string value
+	Value string
+}
+
+func (sl *StringLiteral) expressionNode() {}
+
+func (sl *StringLiteral) TokenLiteral() string {
+	return sl.Token.Literal
+}
+
+func (sl *StringLiteral) String() string {
+	return strconv.Quote(sl.Value)
+}
+
+func (sl *StringLiteral) Pos() lexer.Position {
+	return sl.Token.Start
+}
+
 type ExpressionStatement struct {
 	Token      lexer.Token
 	Expression Expression
@@ -84,6 +172,14 @@ func (p *Program) TokenLiteral() string {
 	}
 }
 
+// Pos returns the position of the first statement, or the zero Position for an empty program.
+func (p *Program) Pos() lexer.Position {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].Pos()
+	}
+	return lexer.Position{}
+}
+
 // statementNode method is a placeholder for any specific statement node.
 type LetStatement struct {
 	Token lexer.Token // the token.Token representing the "let" keyword
@@ -97,6 +193,10 @@ func (ls *LetStatement) TokenLiteral() string {
 	return ls.Token.Literal
 }
 
+func (ls *LetStatement) Pos() lexer.Position {
+	return ls.Token.Start
+}
+
 type Identifier struct {
 	Token lexer.Token // the token.Token representing the identifier
 	Value string
@@ -112,6 +212,10 @@ func (i *Identifier) String() string {
 	return i.Value
 }
 
+func (i *Identifier) Pos() lexer.Position {
+	return i.Token.Start
+}
+
 type ReturnStatement struct {
 	Token       lexer.Token // the token.Token representing the "return" keyword
 	ReturnValue Expression
@@ -123,12 +227,20 @@ func (rs *ReturnStatement) TokenLiteral() string {
 	return rs.Token.Literal
 }
 
+func (rs *ReturnStatement) Pos() lexer.Position {
+	return rs.Token.Start
+}
+
 func (es *ExpressionStatement) statementNode() {}
 
 func (es *ExpressionStatement) TokenLiteral() string {
 	return es.Token.Literal
 }
 
+func (es *ExpressionStatement) Pos() lexer.Position {
+	return es.Token.Start
+}
+
 func (p *Program) String() string {
 	var out bytes.Buffer
 
@@ -145,8 +257,9 @@ func (ls *LetStatement) String() string {
 	out.WriteString(" = ")
 
 	if ls.Value != nil {
-		out.WriteString(ls.Name.Value)
+		out.WriteString(ls.Value.String())
 	}
+	out.WriteString(";")
 	return out.String()
 }
 
@@ -168,3 +281,1000 @@ func (es *ExpressionStatement) String() string {
 	}
 	return ""
 }
+
+// BlockStatement is a sequence of statements between braces, such as the
+// body of a function.
+type BlockStatement struct {
+	Token      lexer.Token // the { token
+	Statements []Statement
+}
+
+func (bs *BlockStatement) statementNode() {}
+
+func (bs *BlockStatement) TokenLiteral() string {
+	return bs.Token.Literal
+}
+
+func (bs *BlockStatement) Pos() lexer.Position {
+	return bs.Token.Start
+}
+
+func (bs *BlockStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("{ ")
+	for _, s := range bs.Statements {
+		out.WriteString(s.String())
+		out.WriteString(" ")
+	}
+	out.WriteString("}")
+	return out.String()
+}
+
+// FunctionLiteral is an anonymous function such as fn(x, y) { x + y }.
+// Parameters and the result may be given types, as in fn(x, y int) int { ... }.
+type FunctionLiteral struct {
+	Token          lexer.Token // the fn or function token
+	Parameters     []*Identifier
+	ParameterTypes []Expression // the type of each parameter, nil where it has none
+	Result         Expression   // the result type, or nil
+	Body           *BlockStatement
+}
+
+func (fl *FunctionLiteral) expressionNode() {}
+
+func (fl *FunctionLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+
+func (fl *FunctionLiteral) Pos() lexer.Position {
+	return fl.Token.Start
+}
+
+func (fl *FunctionLiteral) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(fl.TokenLiteral())
+	out.WriteString(fl.signature())
+	out.WriteString(fl.Body.String())
+	return out.String()
+}
+
+// signature formats the parameter list and result type, followed by a space.
+func (fl *FunctionLiteral) signature() string {
+	params := make([]string, len(fl.Parameters))
+	for i, param := range fl.Parameters {
+		params[i] = param.String()
+		if i < len(fl.ParameterTypes) && fl.ParameterTypes[i] != nil {
+			params[i] += " " + fl.ParameterTypes[i].String()
+		}
+	}
+
+	out := "(" + strings.Join(params, ", ") + ") "
+	if fl.Result != nil {
+		out += fl.Result.String() + " "
+	}
+	return out
+}
+
+// FunctionStatement declares a named function: fn add(x, y) { x + y }.
+type FunctionStatement struct {
+	Token    lexer.Token // the fn or function token
+	Name     *Identifier
+	Function *FunctionLiteral
+}
+
+func (fs *FunctionStatement) statementNode() {}
+
+func (fs *FunctionStatement) TokenLiteral() string {
+	return fs.Token.Literal
+}
+
+func (fs *FunctionStatement) Pos() lexer.Position {
+	return fs.Token.Start
+}
+
+func (fs *FunctionStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(fs.TokenLiteral() + " ")
+	out.WriteString(fs.Name.String())
+	out.WriteString(fs.Function.signature())
+	out.WriteString(fs.Function.Body.String())
+	return out.String()
+}
+
+// PointerType is *Base, the type of a pointer receiver.
+type PointerType struct {
+	Token lexer.Token // the * token
+	Base  Expression
+}
+
+func (pt *PointerType) expressionNode() {}
+
+func (pt *PointerType) TokenLiteral() string {
+	return pt.Token.Literal
+}
+
+func (pt *PointerType) Pos() lexer.Position {
+	return pt.Token.Start
+}
+
+func (pt *PointerType) String() string {
+	return "*" + pt.Base.String()
+}
+
+// MethodStatement declares a method of a struct type:
+// fn (p Point) Dist() float { ... }. A receiver of type *Point makes a
+// pointer receiver, through which the method changes the value it is called
+// on rather than a copy.
+type MethodStatement struct {
+	Token        lexer.Token // the fn token
+	Receiver     *Identifier
+	ReceiverType Expression // an Identifier, or a PointerType to one
+	Name         *Identifier
+	Function     *FunctionLiteral
+}
+
+func (ms *MethodStatement) statementNode() {}
+
+func (ms *MethodStatement) TokenLiteral() string {
+	return ms.Token.Literal
+}
+
+func (ms *MethodStatement) Pos() lexer.Position {
+	return ms.Token.Start
+}
+
+func (ms *MethodStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ms.TokenLiteral() + " ")
+	out.WriteString("(" + ms.Receiver.String() + " " + ms.ReceiverType.String() + ") ")
+	out.WriteString(ms.Name.String())
+	out.WriteString(ms.Function.signature())
+	out.WriteString(ms.Function.Body.String())
+	return out.String()
+}
+
+// CallExpression calls Function, an identifier or any expression evaluating
+// to a function, with Arguments.
+type CallExpression struct {
+	Token     lexer.Token // the ( token
+	Function  Expression
+	Arguments []Expression
+}
+
+func (ce *CallExpression) expressionNode() {}
+
+func (ce *CallExpression) TokenLiteral() string {
+	return ce.Token.Literal
+}
+
+func (ce *CallExpression) Pos() lexer.Position {
+	return ce.Function.Pos()
+}
+
+func (ce *CallExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ce.Function.String())
+	out.WriteString("(")
+	out.WriteString(joinExpressions(ce.Arguments))
+	out.WriteString(")")
+	return out.String()
+}
+
+// joinExpressions formats a parameter or argument list.
+func joinExpressions[E Expression](list []E) string {
+	parts := make([]string, len(list))
+	for i, e := range list {
+		parts[i] = e.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Boolean is the literal true or false.
+type Boolean struct {
+	Token lexer.Token
+	Value bool
+}
+
+func (b *Boolean) expressionNode() {}
+
+func (b *Boolean) TokenLiteral() string {
+	return b.Token.Literal
+}
+
+func (b *Boolean) String() string {
+	return b.Token.Literal
+}
+
+func (b *Boolean) Pos() lexer.Position {
+	return b.Token.Start
+}
+
+// NullLiteral is the literal null.
+type NullLiteral struct {
+	Token lexer.Token
+}
+
+func (nl *NullLiteral) expressionNode() {}
+
+func (nl *NullLiteral) TokenLiteral() string {
+	return nl.Token.Literal
+}
+
+func (nl *NullLiteral) String() string {
+	return "null"
+}
+
+func (nl *NullLiteral) Pos() lexer.Position {
+	return nl.Token.Start
+}
+
+// IfExpression is if cond { ... } with an optional else branch. An else-if
+// chain is represented as an Alternative block holding a single nested
+// IfExpression. Its value is that of the branch taken.
+type IfExpression struct {
+	Token       lexer.Token // the if token
+	Condition   Expression
+	Consequence *BlockStatement
+	Alternative *BlockStatement
+}
+
+func (ie *IfExpression) expressionNode() {}
+
+func (ie *IfExpression) TokenLiteral() string {
+	return ie.Token.Literal
+}
+
+func (ie *IfExpression) Pos() lexer.Position {
+	return ie.Token.Start
+}
+
+func (ie *IfExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("if ")
+	out.WriteString(ie.Condition.String())
+	out.WriteString(" ")
+	out.WriteString(ie.Consequence.String())
+
+	if ie.Alternative != nil {
+		out.WriteString(" else ")
+		out.WriteString(ie.Alternative.String())
+	}
+	return out.String()
+}
+
+// TernaryExpression is cond ? a : b, which evaluates a if cond is truthy
+// and b otherwise.
+type TernaryExpression struct {
+	Token       lexer.Token // the ? token
+	Condition   Expression
+	Consequence Expression
+	Alternative Expression
+}
+
+func (te *TernaryExpression) expressionNode() {}
+
+func (te *TernaryExpression) TokenLiteral() string {
+	return te.Token.Literal
+}
+
+func (te *TernaryExpression) Pos() lexer.Position {
+	return te.Condition.Pos()
+}
+
+func (te *TernaryExpression) String() string {
+	return "(" + te.Condition.String() + " ? " + te.Consequence.String() + " : " + te.Alternative.String() + ")"
+}
+
+// WhileStatement is while cond { ... }.
+type WhileStatement struct {
+	Token     lexer.Token // the while token
+	Condition Expression
+	Body      *BlockStatement
+}
+
+func (ws *WhileStatement) statementNode() {}
+
+func (ws *WhileStatement) TokenLiteral() string {
+	return ws.Token.Literal
+}
+
+func (ws *WhileStatement) Pos() lexer.Position {
+	return ws.Token.Start
+}
+
+func (ws *WhileStatement) String() string {
+	return "while " + ws.Condition.String() + " " + ws.Body.String()
+}
+
+// ForStatement is a for loop in any of its forms: for { ... },
+// for cond { ... } and for init; cond; post { ... }. Any of Init, Condition
+// and Post may be nil; a nil Condition is always true.
+type ForStatement struct {
+	Token     lexer.Token // the for token
+	Init      Statement
+	Condition Expression
+	Post      Statement
+	Body      *BlockStatement
+}
+
+func (fs *ForStatement) statementNode() {}
+
+func (fs *ForStatement) TokenLiteral() string {
+	return fs.Token.Literal
+}
+
+func (fs *ForStatement) Pos() lexer.Position {
+	return fs.Token.Start
+}
+
+func (fs *ForStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("for ")
+	if fs.Init != nil || fs.Post != nil {
+		var header bytes.Buffer
+		if fs.Init != nil {
+			header.WriteString(strings.TrimSuffix(fs.Init.String(), ";"))
+		}
+		header.WriteString("; ")
+		if fs.Condition != nil {
+			header.WriteString(fs.Condition.String())
+		}
+		header.WriteString("; ")
+		if fs.Post != nil {
+			header.WriteString(strings.TrimSuffix(fs.Post.String(), ";"))
+		}
+		out.WriteString(strings.TrimSpace(header.String()) + " ")
+	} else if fs.Condition != nil {
+		out.WriteString(fs.Condition.String() + " ")
+	}
+	out.WriteString(fs.Body.String())
+	return out.String()
+}
+
+// ForeachStatement is foreach value in collection { ... } or
+// foreach index, value in collection { ... }. Over a hash, index is the key;
+// with a single variable a hash yields its keys.
+type ForeachStatement struct {
+	Token    lexer.Token // the foreach token
+	Index    *Identifier // nil when only one variable is given
+	Value    *Identifier
+	Iterable Expression
+	Body     *BlockStatement
+}
+
+func (fs *ForeachStatement) statementNode() {}
+
+func (fs *ForeachStatement) TokenLiteral() string {
+	return fs.Token.Literal
+}
+
+func (fs *ForeachStatement) Pos() lexer.Position {
+	return fs.Token.Start
+}
+
+func (fs *ForeachStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("foreach ")
+	if fs.Index != nil {
+		out.WriteString(fs.Index.String() + ", ")
+	}
+	out.WriteString(fs.Value.String())
+	out.WriteString(" in ")
+	out.WriteString(fs.Iterable.String())
+	out.WriteString(" ")
+	out.WriteString(fs.Body.String())
+	return out.String()
+}
+
+// BranchStatement is break or continue, with an optional label naming the
+// loop it applies to.
+type BranchStatement struct {
+	Token lexer.Token // the break or continue token
+	Label *Identifier // nil for the innermost loop
+}
+
+func (bs *BranchStatement) statementNode() {}
+
+func (bs *BranchStatement) TokenLiteral() string {
+	return bs.Token.Literal
+}
+
+func (bs *BranchStatement) Pos() lexer.Position {
+	return bs.Token.Start
+}
+
+func (bs *BranchStatement) String() string {
+	if bs.Label != nil {
+		return bs.TokenLiteral() + " " + bs.Label.String() + ";"
+	}
+	return bs.TokenLiteral() + ";"
+}
+
+// LabeledStatement is a loop preceded by label:, which break and continue
+// statements inside it can name.
+type LabeledStatement struct {
+	Token     lexer.Token // the label identifier
+	Label     *Identifier
+	Statement Statement
+}
+
+func (ls *LabeledStatement) statementNode() {}
+
+func (ls *LabeledStatement) TokenLiteral() string {
+	return ls.Token.Literal
+}
+
+func (ls *LabeledStatement) Pos() lexer.Position {
+	return ls.Token.Start
+}
+
+func (ls *LabeledStatement) String() string {
+	return ls.Label.String() + ": " + ls.Statement.String()
+}
+
+// ArrayType is the type []Element, as in the typed array literal []int{1, 2}.
+type ArrayType struct {
+	Token   lexer.Token // the [ token
+	Element Expression  // the element type: an Identifier or another ArrayType
+}
+
+func (at *ArrayType) expressionNode() {}
+
+func (at *ArrayType) TokenLiteral() string {
+	return at.Token.Literal
+}
+
+func (at *ArrayType) Pos() lexer.Position {
+	return at.Token.Start
+}
+
+func (at *ArrayType) String() string {
+	return "[]" + at.Element.String()
+}
+
+// ArrayLiteral is [1, 2, 3], or with an element type, []int{1, 2, 3}.
+type ArrayLiteral struct {
+	Token    lexer.Token // the [ token
+	Type     *ArrayType  // nil for an untyped literal
+	Elements []Expression
+}
+
+func (al *ArrayLiteral) expressionNode() {}
+
+func (al *ArrayLiteral) TokenLiteral() string {
+	return al.Token.Literal
+}
+
+func (al *ArrayLiteral) Pos() lexer.Position {
+	return al.Token.Start
+}
+
+func (al *ArrayLiteral) String() string {
+	if al.Type != nil {
+		return al.Type.String() + "{" + joinExpressions(al.Elements) + "}"
+	}
+	return "[" + joinExpressions(al.Elements) + "]"
+}
+
+// HashLiteralPair is one key: value entry of a HashLiteral.
+type HashLiteralPair struct {
+	Key   Expression
+	Value Expression
+}
+
+// HashLiteral is {key: value, ...}. Pairs are kept in source order, which
+// is the order the resulting hash iterates in.
+type HashLiteral struct {
+	Token lexer.Token // the { token
+	Pairs []HashLiteralPair
+}
+
+func (hl *HashLiteral) expressionNode() {}
+
+func (hl *HashLiteral) TokenLiteral() string {
+	return hl.Token.Literal
+}
+
+func (hl *HashLiteral) Pos() lexer.Position {
+	return hl.Token.Start
+}
+
+func (hl *HashLiteral) String() string {
+	pairs := make([]string, len(hl.Pairs))
+	for i, pair := range hl.Pairs {
+		pairs[i] = pair.Key.String() + ": " + pair.Value.String()
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// IndexExpression is Left[Index], an element of an array, string or hash.
+type IndexExpression struct {
+	Token lexer.Token // the [ token
+	Left  Expression
+	Index Expression
+}
+
+func (ie *IndexExpression) expressionNode() {}
+
+func (ie *IndexExpression) TokenLiteral() string {
+	return ie.Token.Literal
+}
+
+func (ie *IndexExpression) Pos() lexer.Position {
+	return ie.Left.Pos()
+}
+
+func (ie *IndexExpression) String() string {
+	return "(" + ie.Left.String() + "[" + ie.Index.String() + "])"
+}
+
+// SliceExpression is Left[Low:High] or Left[Low:High:Max]. Low and High are
+// nil when omitted; Max is nil unless the three-index form is used.
+type SliceExpression struct {
+	Token lexer.Token // the [ token
+	Left  Expression
+	Low   Expression
+	High  Expression
+	Max   Expression
+}
+
+func (se *SliceExpression) expressionNode() {}
+
+func (se *SliceExpression) TokenLiteral() string {
+	return se.Token.Literal
+}
+
+func (se *SliceExpression) Pos() lexer.Position {
+	return se.Left.Pos()
+}
+
+func (se *SliceExpression) String() string {
+	var out strings.Builder
+
+	out.WriteString("(" + se.Left.String() + "[")
+	if se.Low != nil {
+		out.WriteString(se.Low.String())
+	}
+	out.WriteString(":")
+	if se.High != nil {
+		out.WriteString(se.High.String())
+	}
+	if se.Max != nil {
+		out.WriteString(":" + se.Max.String())
+	}
+	out.WriteString("])")
+	return out.String()
+}
+
+// RangeExpression is Start..End, which includes End, or Start..<End, which
+// stops before it.
+type RangeExpression struct {
+	Token    lexer.Token // the .. or ..< token
+	Start    Expression
+	Operator string
+	End      Expression
+}
+
+func (re *RangeExpression) expressionNode() {}
+
+func (re *RangeExpression) TokenLiteral() string {
+	return re.Token.Literal
+}
+
+func (re *RangeExpression) Pos() lexer.Position {
+	return re.Start.Pos()
+}
+
+func (re *RangeExpression) String() string {
+	return "(" + re.Start.String() + re.Operator + re.End.String() + ")"
+}
+
+// AssignStatement stores values into existing variables or elements:
+// x = v, a[i] = v, p.x = v, the compound forms x += v, x -= v, x *= v and
+// x /= v, and the tuple form a, b = b, a. Compound assignments have exactly
+// one target and one value.
+type AssignStatement struct {
+	Token   lexer.Token // the = or op= token
+	Targets []Expression
+	Values  []Expression
+}
+
+func (as *AssignStatement) statementNode() {}
+
+func (as *AssignStatement) TokenLiteral() string {
+	return as.Token.Literal
+}
+
+func (as *AssignStatement) Pos() lexer.Position {
+	return as.Targets[0].Pos()
+}
+
+func (as *AssignStatement) String() string {
+	return joinExpressions(as.Targets) + " " + as.Token.Literal + " " + joinExpressions(as.Values) + ";"
+}
+
+// IncDecStatement is x++ or x--.
+type IncDecStatement struct {
+	Token  lexer.Token // the ++ or -- token
+	Target Expression
+}
+
+func (is *IncDecStatement) statementNode() {}
+
+func (is *IncDecStatement) TokenLiteral() string {
+	return is.Token.Literal
+}
+
+func (is *IncDecStatement) Pos() lexer.Position {
+	return is.Target.Pos()
+}
+
+func (is *IncDecStatement) String() string {
+	return is.Target.String() + is.Token.Literal + ";"
+}
+
+// SelectorExpression is X.Sel, a field or method of X.
+type SelectorExpression struct {
+	Token lexer.Token // the . token
+	X     Expression
+	Sel   *Identifier
+}
+
+func (se *SelectorExpression) expressionNode() {}
+
+func (se *SelectorExpression) TokenLiteral() string {
+	return se.Token.Literal
+}
+
+func (se *SelectorExpression) Pos() lexer.Position {
+	return se.X.Pos()
+}
+
+func (se *SelectorExpression) String() string {
+	return se.X.String() + "." + se.Sel.String()
+}
+
+// ValueSpec declares one name in a var or const statement: name type = value.
+// Type is nil when the type is left to the value, and Value is nil in a var
+// declaration that starts from the type's zero value.
+type ValueSpec struct {
+	Name  *Identifier
+	Type  Expression
+	Value Expression
+}
+
+func (vs *ValueSpec) String() string {
+	out := vs.Name.String()
+	if vs.Type != nil {
+		out += " " + vs.Type.String()
+	}
+	if vs.Value != nil {
+		out += " = " + vs.Value.String()
+	}
+	return out
+}
+
+// joinSpecs writes the specs of a var or const statement after its keyword,
+// in parentheses if the statement was written as a group.
+func joinSpecs(keyword string, specs []*ValueSpec, grouped bool) string {
+	if !grouped && len(specs) == 1 {
+		return keyword + " " + specs[0].String() + ";"
+	}
+
+	var out strings.Builder
+	out.WriteString(keyword + " (")
+	for _, spec := range specs {
+		out.WriteString(spec.String() + ";")
+	}
+	out.WriteString(")")
+	return out.String()
+}
+
+// VarStatement declares variables: var x int = 1, or several at once in a
+// group such as var ( x = 1; y string ).
+type VarStatement struct {
+	Token   lexer.Token // the var token
+	Specs   []*ValueSpec
+	Grouped bool
+}
+
+func (vs *VarStatement) statementNode() {}
+
+func (vs *VarStatement) TokenLiteral() string {
+	return vs.Token.Literal
+}
+
+func (vs *VarStatement) Pos() lexer.Position {
+	return vs.Token.Start
+}
+
+func (vs *VarStatement) String() string {
+	return joinSpecs(vs.TokenLiteral(), vs.Specs, vs.Grouped)
+}
+
+// ConstStatement declares constants, names that cannot be bound again once
+// declared. Every spec has a Value.
+type ConstStatement struct {
+	Token   lexer.Token // the const token
+	Specs   []*ValueSpec
+	Grouped bool
+}
+
+func (cs *ConstStatement) statementNode() {}
+
+func (cs *ConstStatement) TokenLiteral() string {
+	return cs.Token.Literal
+}
+
+func (cs *ConstStatement) Pos() lexer.Position {
+	return cs.Token.Start
+}
+
+func (cs *ConstStatement) String() string {
+	return joinSpecs(cs.TokenLiteral(), cs.Specs, cs.Grouped)
+}
+
+// ShortVarDeclaration is name := value, a var declaration that takes its type
+// from the value.
+type ShortVarDeclaration struct {
+	Token lexer.Token // the := token
+	Name  *Identifier
+	Value Expression
+}
+
+func (sd *ShortVarDeclaration) statementNode() {}
+
+func (sd *ShortVarDeclaration) TokenLiteral() string {
+	return sd.Token.Literal
+}
+
+func (sd *ShortVarDeclaration) Pos() lexer.Position {
+	return sd.Name.Pos()
+}
+
+func (sd *ShortVarDeclaration) String() string {
+	return sd.Name.String() + " := " + sd.Value.String() + ";"
+}
+
+// Field is one field of a StructType. An embedded field has no Name and is
+// known by the name of its Type, an Identifier.
+type Field struct {
+	Name *Identifier // nil for an embedded field
+	Type Expression
+}
+
+// FieldName returns the name the field is selected by.
+func (f *Field) FieldName() string {
+	if f.Name == nil {
+		return f.Type.String()
+	}
+	return f.Name.Value
+}
+
+func (f *Field) Pos() lexer.Position {
+	if f.Name == nil {
+		return f.Type.Pos()
+	}
+	return f.Name.Pos()
+}
+
+func (f *Field) String() string {
+	if f.Name == nil {
+		return f.Type.String()
+	}
+	return f.Name.String() + " " + f.Type.String()
+}
+
+// StructType is struct { X int; Y int }, the type in a type statement, the
+// type of a field or the type of an anonymous struct literal.
+type StructType struct {
+	Token  lexer.Token // the struct token
+	Fields []*Field
+}
+
+func (st *StructType) expressionNode() {}
+
+func (st *StructType) TokenLiteral() string {
+	return st.Token.Literal
+}
+
+func (st *StructType) Pos() lexer.Position {
+	return st.Token.Start
+}
+
+func (st *StructType) String() string {
+	if len(st.Fields) == 0 {
+		return "struct {}"
+	}
+	fields := make([]string, len(st.Fields))
+	for i, field := range st.Fields {
+		fields[i] = field.String()
+	}
+	return "struct { " + strings.Join(fields, "; ") + " }"
+}
+
+// TypeStatement declares a named struct type: type Point struct { X int; Y int }.
+type TypeStatement struct {
+	Token lexer.Token // the type token
+	Name  *Identifier
+	Type  *StructType
+}
+
+func (ts *TypeStatement) statementNode() {}
+
+func (ts *TypeStatement) TokenLiteral() string {
+	return ts.Token.Literal
+}
+
+func (ts *TypeStatement) Pos() lexer.Position {
+	return ts.Token.Start
+}
+
+func (ts *TypeStatement) String() string {
+	return "type " + ts.Name.String() + " " + ts.Type.String() + ";"
+}
+
+// KeyValueExpression is Key: Value, a keyed element of a CompositeLiteral.
+type KeyValueExpression struct {
+	Token lexer.Token // the : token
+	Key   Expression
+	Value Expression
+}
+
+func (kv *KeyValueExpression) expressionNode() {}
+
+func (kv *KeyValueExpression) TokenLiteral() string {
+	return kv.Token.Literal
+}
+
+func (kv *KeyValueExpression) Pos() lexer.Position {
+	return kv.Key.Pos()
+}
+
+func (kv *KeyValueExpression) String() string {
+	return kv.Key.String() + ": " + kv.Value.String()
+}
+
+// CompositeLiteral is Type{...}, a value of a struct type. Its elements are
+// either all KeyValueExpressions naming fields, as in Point{X: 1, Y: 2}, or
+// all plain values given in field order, as in Point{1, 2}.
+type CompositeLiteral struct {
+	Token    lexer.Token // the { token
+	Type     Expression  // an Identifier naming the type, or a StructType
+	Elements []Expression
+}
+
+func (cl *CompositeLiteral) expressionNode() {}
+
+func (cl *CompositeLiteral) TokenLiteral() string {
+	return cl.Token.Literal
+}
+
+func (cl *CompositeLiteral) Pos() lexer.Position {
+	return cl.Type.Pos()
+}
+
+func (cl *CompositeLiteral) String() string {
+	return cl.Type.String() + "{" + joinExpressions(cl.Elements) + "}"
+}
+
+// ClassStatement declares a class: class Dog extends Animal { ... }. Its
+// methods are written without fn; the one named constructor initialises the
+// instances created by new.
+type ClassStatement struct {
+	Token      lexer.Token // the class token
+	Name       *Identifier
+	SuperClass *Identifier // nil if the class extends nothing
+	Methods    []*ClassMethod
+}
+
+func (cs *ClassStatement) statementNode() {}
+
+func (cs *ClassStatement) TokenLiteral() string {
+	return cs.Token.Literal
+}
+
+func (cs *ClassStatement) Pos() lexer.Position {
+	return cs.Token.Start
+}
+
+func (cs *ClassStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("class " + cs.Name.String())
+	if cs.SuperClass != nil {
+		out.WriteString(" extends " + cs.SuperClass.String())
+	}
+	if len(cs.Methods) == 0 {
+		out.WriteString(" {}")
+		return out.String()
+	}
+	out.WriteString(" { ")
+	for _, method := range cs.Methods {
+		out.WriteString(method.String() + " ")
+	}
+	out.WriteString("}")
+	return out.String()
+}
+
+// ClassMethod is a method in the body of a class: name(params) { body }.
+type ClassMethod struct {
+	Name     *Identifier
+	Function *FunctionLiteral
+}
+
+func (cm *ClassMethod) Pos() lexer.Position {
+	return cm.Name.Pos()
+}
+
+func (cm *ClassMethod) String() string {
+	return cm.Name.String() + cm.Function.signature() + cm.Function.Body.String()
+}
+
+// NewExpression is new Class(args), which creates an instance of Class.
+type NewExpression struct {
+	Token     lexer.Token // the new token
+	Class     Expression
+	Arguments []Expression
+}
+
+func (ne *NewExpression) expressionNode() {}
+
+func (ne *NewExpression) TokenLiteral() string {
+	return ne.Token.Literal
+}
+
+func (ne *NewExpression) Pos() lexer.Position {
+	return ne.Token.Start
+}
+
+func (ne *NewExpression) String() string {
+	return "new " + ne.Class.String() + "(" + joinExpressions(ne.Arguments) + ")"
+}
+
+// ThisExpression is this, the instance a class method was called on.
+type ThisExpression struct {
+	Token lexer.Token // the this token
+}
+
+func (te *ThisExpression) expressionNode() {}
+
+func (te *ThisExpression) TokenLiteral() string {
+	return te.Token.Literal
+}
+
+func (te *ThisExpression) Pos() lexer.Position {
+	return te.Token.Start
+}
+
+func (te *ThisExpression) String() string {
+	return "this"
+}
+
+// SuperExpression is super, which only appears called, as super(args) to
+// run the superclass constructor, or selected from, as super.method.
+type SuperExpression struct {
+	Token lexer.Token // the super token
+}
+
+func (se *SuperExpression) expressionNode() {}
+
+func (se *SuperExpression) TokenLiteral() string {
+	return se.Token.Literal
+}
+
+func (se *SuperExpression) Pos() lexer.Position {
+	return se.Token.Start
+}
+
+func (se *SuperExpression) String() string {
+	return "super"
+}
@@ -1,9 +1,12 @@
 package ast
 
+import "kisumu/pkg/lexer"
+
 type Node interface {
 	// Add methods for each node type
 	TokenLiteral() string
 	String() string
+	Pos() lexer.Position // position of the first token of the node
 }
 
 type Statement interface {
@@ -0,0 +1,163 @@
+package interpreter
+
+import (
+	"strings"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/object"
+)
+
+// location is a place a value can be stored: a variable, or an element or
+// field of a container. The container and index are evaluated once, when the
+// location is found, so a compound assignment such as a[f()] += 1 calls f a
+// single time.
+type location struct {
+	name      string        // the variable, when container is nil
+	container object.Object // the value holding the element or field
+	index     object.Object // the index of an element
+	field     string        // the name of a field, if the location is one
+}
+
+func evalLocation(target ast.Expression, env *Environment) (*location, object.Object) {
+	switch target := target.(type) {
+	case *ast.Identifier:
+		return &location{name: target.Value}, nil
+
+	case *ast.IndexExpression:
+		container := Eval(target.Left, env)
+		if isError(container) {
+			return nil, container
+		}
+		index := Eval(target.Index, env)
+		if isError(index) {
+			return nil, index
+		}
+		return &location{container: container, index: index}, nil
+
+	case *ast.SelectorExpression:
+		container := Eval(target.X, env)
+		if isError(container) {
+			return nil, container
+		}
+		return &location{container: container, field: target.Sel.Value}, nil
+
+	default:
+		return nil, object.NewError("cannot assign to %s", target)
+	}
+}
+
+func (l *location) get(env *Environment) object.Object {
+	switch {
+	case l.container == nil:
+		if val, ok := env.Get(l.name); ok {
+			return val
+		}
+		return object.NewError("identifier not found: %s", l.name)
+	case l.field != "":
+		return evalSelector(l.container, l.field)
+	default:
+		return evalIndexExpression(l.container, l.index)
+	}
+}
+
+// set stores val at the location, returning an error object if it cannot.
+func (l *location) set(env *Environment, val object.Object) object.Object {
+	if l.container == nil {
+		if err := env.Assign(l.name, val); err != nil {
+			return err
+		}
+		return nil
+	}
+	if l.field != "" {
+		return setField(l.container, l.field, val)
+	}
+
+	switch container := l.container.(type) {
+	case *object.Array:
+		i, err := checkIndex(l.index, container.Length())
+		if err != nil {
+			return err
+		}
+		container.Elements[i] = copyValue(val)
+
+	case *object.Hash:
+		if err := container.Set(l.index, copyValue(val)); err != nil {
+			return object.NewError("%s", err)
+		}
+
+	case *object.String:
+		return object.NewError("cannot assign to an element of a STRING: strings are immutable")
+
+	default:
+		return object.NewError("index assignment not supported: %s", container.Type())
+	}
+	return nil
+}
+
+// evalAssignStatement evaluates the targets, then the values, and then
+// stores the values from left to right, so a, b = b, a swaps a and b. A
+// compound assignment x op= v stores x op v.
+func evalAssignStatement(node *ast.AssignStatement, env *Environment) object.Object {
+	locations := make([]*location, len(node.Targets))
+	for i, target := range node.Targets {
+		loc, err := evalLocation(target, env)
+		if err != nil {
+			return err
+		}
+		locations[i] = loc
+	}
+
+	values := evalExpressions(node.Values, env)
+	if len(values) == 1 && isError(values[0]) {
+		return values[0]
+	}
+
+	if node.Token.Type != lexer.ASSIGNMENT {
+		current := locations[0].get(env)
+		if isError(current) {
+			return current
+		}
+		operator := strings.TrimSuffix(node.Token.Literal, "=")
+		values[0] = evalInfixExpression(operator, current, values[0])
+		if isError(values[0]) {
+			return values[0]
+		}
+	}
+
+	for i, loc := range locations {
+		if err := loc.set(env, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evalIncDecStatement adds 1 to or subtracts 1 from a number or rune.
+func evalIncDecStatement(node *ast.IncDecStatement, env *Environment) object.Object {
+	loc, err := evalLocation(node.Target, env)
+	if err != nil {
+		return err
+	}
+	current := loc.get(env)
+	if isError(current) {
+		return current
+	}
+
+	delta := int64(1)
+	if node.Token.Type == lexer.MINUS_MINUS {
+		delta = -1
+	}
+
+	var val object.Object
+	switch {
+	case current.Type() == object.RUNE_OBJ:
+		val = &object.Rune{Value: current.(*object.Rune).Value + rune(delta)}
+	case isNumber(current):
+		val = evalInfixExpression("+", current, &object.Integer{Value: delta})
+	default:
+		return object.NewError("invalid operation: %s%s (non-numeric type %s)",
+			node.Target, node.Token.Literal, current.Type())
+	}
+	return loc.set(env, val)
+}
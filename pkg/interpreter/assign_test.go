@@ -0,0 +1,111 @@
+package interpreter
+
+import (
+	"testing"
+
+	"kisumu/pkg/object"
+)
+
+func TestAssignStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 1; x = 5; x", "5"},
+		{"x := 1; x += 2; x", "3"},
+		{"x := 10; x -= 4; x", "6"},
+		{"x := 3; x *= 4; x", "12"},
+		{"x := 12; x /= 5; x", "2"},
+		{"x := 1.5; x *= 2; x", "3.0"},
+		{`s := "ab"; s += "c"; s`, `"abc"`},
+		{`s := "ab"; s += 'c'; s`, `"abc"`},
+		{"a := 1; b := 2; a, b = b, a; [a, b]", "[2, 1]"},
+		{"a := 1; b := 2; c := 3; a, b, c = c, a, b; [a, b, c]", "[3, 1, 2]"},
+		{"xs := [1, 2, 3]; xs[0], xs[2] = xs[2], xs[0]; xs", "[3, 2, 1]"},
+		{"xs := [1, 2, 3]; xs[1] -= 5; xs", "[1, -3, 3]"},
+		{"xs := [1, 2, 3]; xs[-1] *= 10; xs", "[1, 2, 30]"},
+		{`h := {"n": 1}; h["n"] += 41; h["m"] = 0; h`, `{"n": 42, "m": 0}`},
+		{"grid := [[1, 2], [3, 4]]; grid[1][0] += 10; grid", "[[1, 2], [13, 4]]"},
+		{"n := 0; xs := [5, 6]; fn next() { n++; n - 1 }\nxs[next()] += 1; [n, xs]", "[1, [6, 6]]"},
+		{"x := 0; fn set() { x = 7 }\nset(); x", "7"},
+		{"fn counter() { let c = 0; fn() { c++; c } }\nlet next = counter(); next(); next(); next()", "3"},
+		{"x := 1; fn shadow() { let x = 2; x = 3; x }\n[shadow(), x]", "[3, 1]"},
+		{"total := 0; for i := 0; i < 5; i++ { total += i }\ntotal", "10"},
+		{"n := 3; while n > 0 { n-- }\nn", "0"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestIncDecStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x := 1; x++; x", "2"},
+		{"x := 1; x--; x--; x", "-1"},
+		{"f := 0.5; f++; f", "1.5"},
+		{"r := 'a'; r++; r", "'b'"},
+		{"xs := [1]; xs[0]++; xs", "[2]"},
+		{`h := {"hits": 0}; h["hits"]++; h["hits"]++; h`, `{"hits": 2}`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestAssignErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"y = 1", "assignment to undeclared variable y"},
+		{"y += 1", "identifier not found: y"},
+		{"y++", "identifier not found: y"},
+		{"fn f() { undeclared = 1 }\nf()", "assignment to undeclared variable undeclared"},
+		{"const k = 1; k = 2", "cannot assign to constant k"},
+		{"const k = 1; k += 1", "cannot assign to constant k"},
+		{"const k = 1; k++", "cannot assign to constant k"},
+		{"const k = 1; fn f() { k = 2 }\nf()", "cannot assign to constant k"},
+		{"a := 1; const b = 2; a, b = b, a", "cannot assign to constant b"},
+		{`s := "x"; s++`, "invalid operation: s++ (non-numeric type STRING)"},
+		{"b := true; b -= 1", "type mismatch: BOOLEAN - INTEGER"},
+		{"x := 1; x /= 0", "division by zero"},
+		{"xs := [1]; xs[1] += 1", "index out of range [1] with length 1"},
+		{`h := {}; h["missing"] += 1`, "type mismatch: NULL + INTEGER"},
+		{`s := "abc"; s[0] = 'x'`, "cannot assign to an element of a STRING: strings are immutable"},
+		{"p := 1; p.x = 2", "INTEGER has no field x"},
+		{"p := 1; p.x += 2", "INTEGER has no field or method x"},
+		{"p := 1; p.x", "INTEGER has no field or method x"},
+		{"x := 1; x = missing", "identifier not found: missing"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expectedMessage, errObj.Message)
+		}
+	}
+}
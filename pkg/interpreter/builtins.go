@@ -0,0 +1,237 @@
+package interpreter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"kisumu/pkg/object"
+)
+
+// Output is where print and println write.
+var Output io.Writer = os.Stdout
+
+var builtins = map[string]*object.Builtin{
+	"len": {Name: "len", Fn: func(args ...object.Object) object.Object {
+		if len(args) != 1 {
+			return object.NewError("wrong number of arguments to len: want=1, got=%d", len(args))
+		}
+		switch arg := args[0].(type) {
+		case *object.String:
+			return &object.Integer{Value: int64(arg.Length())}
+		case *object.Array:
+			return &object.Integer{Value: int64(arg.Length())}
+		case *object.Hash:
+			return &object.Integer{Value: int64(arg.Length())}
+		case *object.Range:
+			return &object.Integer{Value: int64(arg.Length())}
+		default:
+			return object.NewError("argument to len not supported, got %s", args[0].Type())
+		}
+	}},
+	"print": {Name: "print", Fn: func(args ...object.Object) object.Object {
+		fmt.Fprint(Output, printable(args))
+		return object.NULL
+	}},
+	"println": {Name: "println", Fn: func(args ...object.Object) object.Object {
+		fmt.Fprintln(Output, printable(args))
+		return object.NULL
+	}},
+}
+
+// printable joins args with spaces. Strings and runes are written as their
+// text rather than quoted.
+func printable(args []object.Object) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		switch arg := arg.(type) {
+		case *object.String:
+			parts[i] = arg.Value
+		case *object.Rune:
+			parts[i] = string(arg.Value)
+		default:
+			parts[i] = arg.Inspect()
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// builtinMethod is the Go implementation of a method of a built-in type.
+type builtinMethod func(receiver object.Object, args ...object.Object) object.Object
+
+// builtinMethods holds the methods of the built-in types by type and name.
+// Selecting one, as in "abc".length, gives a builtin function bound to the
+// receiver.
+var builtinMethods = map[object.ObjectType]map[string]builtinMethod{
+	object.STRING_OBJ: {
+		"length": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkArgs("length", args, 0); err != nil {
+				return err
+			}
+			return &object.Integer{Value: int64(receiver.(*object.String).Length())}
+		},
+		"substring": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkArgs("substring", args, 2); err != nil {
+				return err
+			}
+			start, ok1 := args[0].(*object.Integer)
+			end, ok2 := args[1].(*object.Integer)
+			if !ok1 || !ok2 {
+				return object.NewError("arguments to substring must be INTEGER, got %s and %s",
+					args[0].Type(), args[1].Type())
+			}
+			s := receiver.(*object.String)
+			sub, ok := s.Substring(int(start.Value), int(end.Value))
+			if !ok {
+				return object.NewError("substring bounds out of range [%d:%d] with length %d",
+					start.Value, end.Value, s.Length())
+			}
+			return sub
+		},
+		"concat": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkArgs("concat", args, 1); err != nil {
+				return err
+			}
+			other, ok := args[0].(*object.String)
+			if !ok {
+				return object.NewError("argument to concat must be STRING, got %s", args[0].Type())
+			}
+			return receiver.(*object.String).Concat(other)
+		},
+	},
+	object.ARRAY_OBJ: {
+		"length": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkArgs("length", args, 0); err != nil {
+				return err
+			}
+			return &object.Integer{Value: int64(receiver.(*object.Array).Length())}
+		},
+		"first": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkArgs("first", args, 0); err != nil {
+				return err
+			}
+			return receiver.(*object.Array).First()
+		},
+		"last": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkArgs("last", args, 0); err != nil {
+				return err
+			}
+			return receiver.(*object.Array).Last()
+		},
+		"get": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkArgs("get", args, 1); err != nil {
+				return err
+			}
+			return evalIndexExpression(receiver, args[0])
+		},
+	},
+	object.HASH_OBJ: {
+		"get": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkArgs("get", args, 1); err != nil {
+				return err
+			}
+			return evalIndexExpression(receiver, args[0])
+		},
+		"set": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkArgs("set", args, 2); err != nil {
+				return err
+			}
+			if err := receiver.(*object.Hash).Set(args[0], copyValue(args[1])); err != nil {
+				return object.NewError("%s", err)
+			}
+			return object.NULL
+		},
+		"keys": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkArgs("keys", args, 0); err != nil {
+				return err
+			}
+			return &object.Array{Elements: receiver.(*object.Hash).Keys()}
+		},
+		"length": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkArgs("length", args, 0); err != nil {
+				return err
+			}
+			return &object.Integer{Value: int64(receiver.(*object.Hash).Length())}
+		},
+	},
+	object.INTEGER_OBJ: numberMethods,
+	object.FLOAT_OBJ:   numberMethods,
+	object.COMPLEX_OBJ: numberMethods,
+	object.BOOLEAN_OBJ: {
+		"not": func(receiver object.Object, args ...object.Object) object.Object {
+			if err := checkArgs("not", args, 0); err != nil {
+				return err
+			}
+			return receiver.(*object.Boolean).Not()
+		},
+		"and": booleanMethod("and", (*object.Boolean).And),
+		"or":  booleanMethod("or", (*object.Boolean).Or),
+	},
+}
+
+// numberMethods are the methods of integers, floats and complex numbers,
+// which follow the arithmetic operators, so (1).add(0.5) is 1.5.
+var numberMethods = map[string]builtinMethod{
+	"add":      arithmeticMethod("add", "+"),
+	"subtract": arithmeticMethod("subtract", "-"),
+	"multiply": arithmeticMethod("multiply", "*"),
+}
+
+// valueMethods are the methods of every value.
+var valueMethods = map[string]builtinMethod{
+	"isNull": func(receiver object.Object, args ...object.Object) object.Object {
+		if err := checkArgs("isNull", args, 0); err != nil {
+			return err
+		}
+		return object.NativeBool(object.IsNull(receiver))
+	},
+	"isNotNull": func(receiver object.Object, args ...object.Object) object.Object {
+		if err := checkArgs("isNotNull", args, 0); err != nil {
+			return err
+		}
+		return object.NativeBool(object.IsNotNull(receiver))
+	},
+}
+
+// lookupBuiltinMethod returns the built-in method name of values of type
+// typ, or nil if there is none.
+func lookupBuiltinMethod(typ object.ObjectType, name string) builtinMethod {
+	if method, ok := builtinMethods[typ][name]; ok {
+		return method
+	}
+	return valueMethods[name]
+}
+
+func arithmeticMethod(name, operator string) builtinMethod {
+	return func(receiver object.Object, args ...object.Object) object.Object {
+		if err := checkArgs(name, args, 1); err != nil {
+			return err
+		}
+		if !isNumber(args[0]) {
+			return object.NewError("argument to %s not supported, got %s", name, args[0].Type())
+		}
+		return evalInfixExpression(operator, receiver, args[0])
+	}
+}
+
+func booleanMethod(name string, op func(*object.Boolean, *object.Boolean) *object.Boolean) builtinMethod {
+	return func(receiver object.Object, args ...object.Object) object.Object {
+		if err := checkArgs(name, args, 1); err != nil {
+			return err
+		}
+		other, ok := args[0].(*object.Boolean)
+		if !ok {
+			return object.NewError("argument to %s must be BOOLEAN, got %s", name, args[0].Type())
+		}
+		return op(receiver.(*object.Boolean), other)
+	}
+}
+
+// checkArgs reports an error unless args holds want arguments to name.
+func checkArgs(name string, args []object.Object, want int) *object.Error {
+	if len(args) != want {
+		return object.NewError("wrong number of arguments to %s: want=%d, got=%d", name, want, len(args))
+	}
+	return nil
+}
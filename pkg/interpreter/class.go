@@ -0,0 +1,271 @@
+package interpreter
+
+import (
+	"bytes"
+	"strings"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/object"
+)
+
+// Class is a class declared by a class statement. Its methods, including
+// the constructor, are closures over the environment the class was
+// declared in.
+type Class struct {
+	Name    string
+	Super   *Class // nil if the class extends nothing
+	Methods map[string]*Function
+}
+
+func (c *Class) Type() object.ObjectType { return object.CLASS_OBJ }
+func (c *Class) Inspect() string         { return "class " + c.Name }
+
+// findMethod finds the method name of c or, if c does not declare it, of its
+// nearest superclass that does. It returns the method and the class that
+// declares it, or nil if there is none.
+func (c *Class) findMethod(name string) (*Function, *Class) {
+	for class := c; class != nil; class = class.Super {
+		if fn, ok := class.Methods[name]; ok {
+			return fn, class
+		}
+	}
+	return nil, nil
+}
+
+// extends reports whether c is base or a subclass of it.
+func (c *Class) extends(base *Class) bool {
+	for class := c; class != nil; class = class.Super {
+		if class == base {
+			return true
+		}
+	}
+	return false
+}
+
+// Instance is an object created by new. Its fields are created by assigning
+// to them, usually as this.name in the constructor. Unlike structs,
+// instances are references: binding one to another name shares it.
+type Instance struct {
+	Class  *Class
+	Fields map[string]object.Object
+	names  []string // the field names, in the order they were created
+}
+
+func (i *Instance) Type() object.ObjectType { return object.INSTANCE_OBJ }
+func (i *Instance) Inspect() string {
+	var out bytes.Buffer
+
+	fields := make([]string, len(i.names))
+	for j, name := range i.names {
+		fields[j] = name + ": " + i.Fields[name].Inspect()
+	}
+
+	out.WriteString(i.Class.Name)
+	out.WriteString("{")
+	out.WriteString(strings.Join(fields, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
+// get returns the field name of i or, if it has none, the method name of its
+// class bound to i. It reports false if there is neither.
+func (i *Instance) get(name string) (object.Object, bool) {
+	if val, ok := i.Fields[name]; ok {
+		return val, true
+	}
+	if fn, home := i.Class.findMethod(name); fn != nil {
+		return bindMethod(fn, home, i), true
+	}
+	return nil, false
+}
+
+// set stores val in the field name of i, creating the field if needed.
+func (i *Instance) set(name string, val object.Object) {
+	if _, ok := i.Fields[name]; !ok {
+		i.names = append(i.names, name)
+	}
+	i.Fields[name] = copyValue(val)
+}
+
+// bindMethod returns fn, a method declared in home, with this bound to
+// instance and super to the superclass of home. super is bound to null in a
+// class that extends nothing, so that it does not resolve to the super of
+// an enclosing method.
+func bindMethod(fn *Function, home *Class, instance *Instance) *Function {
+	env := NewEnclosedEnvironment(fn.Env)
+	// this and super are keywords, so these names cannot clash with a variable.
+	env.Set("this", instance)
+	if home.Super != nil {
+		env.Set("super", home.Super)
+	} else {
+		env.Set("super", object.NULL)
+	}
+
+	bound := *fn
+	bound.Env = env
+	return &bound
+}
+
+// evalClassStatement binds the name of a class declaration.
+func evalClassStatement(node *ast.ClassStatement, env *Environment) object.Object {
+	class := &Class{Name: node.Name.Value, Methods: make(map[string]*Function)}
+
+	if node.SuperClass != nil {
+		val := Eval(node.SuperClass, env)
+		if isError(val) {
+			return val
+		}
+		super, ok := val.(*Class)
+		if !ok {
+			return object.NewError("%s is not a class", node.SuperClass)
+		}
+		class.Super = super
+	}
+
+	for _, method := range node.Methods {
+		name := method.Name.Value
+		class.Methods[name] = newFunction(class.Name+"."+name, method.Function, env)
+	}
+
+	if err := env.Define(class.Name, class); err != nil {
+		return err
+	}
+	return nil
+}
+
+// evalNewExpression creates an instance of a class and runs its
+// constructor, which may be inherited, on it.
+func evalNewExpression(node *ast.NewExpression, env *Environment) object.Object {
+	val := Eval(node.Class, env)
+	if isError(val) {
+		return val
+	}
+	class, ok := val.(*Class)
+	if !ok {
+		return object.NewError("%s is not a class", node.Class)
+	}
+
+	args := evalExpressions(node.Arguments, env)
+	if len(args) == 1 && isError(args[0]) {
+		return args[0]
+	}
+
+	instance := &Instance{Class: class, Fields: make(map[string]object.Object)}
+	if err := construct(class, instance, args); err != nil {
+		return err
+	}
+	return instance
+}
+
+// construct runs the constructor of class, or of its nearest superclass
+// that has one, on instance. A class without a constructor takes no
+// arguments.
+func construct(class *Class, instance *Instance, args []object.Object) object.Object {
+	fn, home := class.findMethod("constructor")
+	if fn == nil {
+		if len(args) != 0 {
+			return object.NewError("wrong number of arguments to %s: want=0, got=%d", class.Name, len(args))
+		}
+		return nil
+	}
+	if result := applyFunction(bindMethod(fn, home, instance), args); isError(result) {
+		return result
+	}
+	return nil
+}
+
+func evalThisExpression(env *Environment) object.Object {
+	if this, ok := env.Get("this"); ok {
+		return this
+	}
+	return object.NewError("cannot use this outside a class method")
+}
+
+// superContext returns the instance and the superclass that super refers
+// to in env.
+func superContext(env *Environment) (*Instance, *Class, object.Object) {
+	this, ok := env.Get("this")
+	if !ok {
+		return nil, nil, object.NewError("cannot use super outside a class method")
+	}
+	super, ok := env.Get("super")
+	class, isClass := super.(*Class)
+	if !ok || !isClass {
+		return nil, nil, object.NewError("cannot use super in a class that extends nothing")
+	}
+	return this.(*Instance), class, nil
+}
+
+// evalSuperCall evaluates super(args), which runs the superclass
+// constructor on this.
+func evalSuperCall(node *ast.CallExpression, env *Environment) object.Object {
+	this, super, err := superContext(env)
+	if err != nil {
+		return err
+	}
+	args := evalExpressions(node.Arguments, env)
+	if len(args) == 1 && isError(args[0]) {
+		return args[0]
+	}
+	if err := construct(super, this, args); err != nil {
+		return err
+	}
+	return object.NULL
+}
+
+// evalSuperSelector evaluates super.name, the superclass's method name bound
+// to this.
+func evalSuperSelector(name string, env *Environment) object.Object {
+	this, super, err := superContext(env)
+	if err != nil {
+		return err
+	}
+	fn, home := super.findMethod(name)
+	if fn == nil {
+		return object.NewError("%s has no method %s", super.Name, name)
+	}
+	return bindMethod(fn, home, this)
+}
+
+// resolveClass returns the class named by typ in env, if it names one.
+func resolveClass(typ *ast.Identifier, env *Environment) (*Class, bool) {
+	val, ok := env.Get(typ.Value)
+	if !ok {
+		return nil, false
+	}
+	class, ok := val.(*Class)
+	return class, ok
+}
+
+// evalInstanceofExpression reports whether left is an instance of the class
+// right or one of its subclasses, or a struct of the struct type right.
+func evalInstanceofExpression(left, right object.Object) object.Object {
+	switch right := right.(type) {
+	case *Class:
+		instance, ok := left.(*Instance)
+		return object.NativeBool(ok && instance.Class.extends(right))
+	case *StructType:
+		s, ok := left.(*Struct)
+		return object.NativeBool(ok && sameType(s.Def, right))
+	}
+	return object.NewError("right operand of instanceof must be a class or struct type, got %s", right.Type())
+}
+
+// typeOf returns the name of the type of val given by typeof: the class of
+// an instance, the type of a struct, and otherwise the name of a built-in
+// type, such as int, string, array or function.
+func typeOf(val object.Object) string {
+	switch val := val.(type) {
+	case *Instance:
+		return val.Class.Name
+	case *Struct:
+		return val.Def.Inspect()
+	}
+	switch val.Type() {
+	case object.INTEGER_OBJ:
+		return "int"
+	case object.FUNCTION_OBJ, object.BUILTIN_OBJ:
+		return "function"
+	}
+	return strings.ToLower(string(val.Type()))
+}
@@ -0,0 +1,155 @@
+package interpreter
+
+import (
+	"testing"
+
+	"kisumu/pkg/object"
+)
+
+const animalClasses = `class Animal {
+	constructor(name) { this.name = name }
+	speak() { this.name + " makes a sound" }
+	describe() { "I am " + this.name }
+}
+class Dog extends Animal {
+	constructor(name, tricks) {
+		super(name)
+		this.tricks = tricks
+	}
+	speak() { super.speak() + ": woof" }
+}
+`
+
+func TestClasses(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{animalClasses + `let a = new Animal("Cat"); a.speak()`, `"Cat makes a sound"`},
+		{animalClasses + `new Dog("Rex", 2).speak()`, `"Rex makes a sound: woof"`},
+		{animalClasses + `new Dog("Rex", 2).describe()`, `"I am Rex"`},
+		{animalClasses + `new Dog("Rex", 2)`, `Dog{name: "Rex", tricks: 2}`},
+		{animalClasses + `let d = new Dog("Rex", 2); d.tricks += 1; d.tricks`, "3"},
+		{animalClasses + `Dog`, "class Dog"},
+		{"class Empty {}\nnew Empty", "Empty{}"},
+		// A class without a constructor inherits its superclass's.
+		{animalClasses + `class Puppy extends Dog {}` + "\n" + `new Puppy("Bit", 0).name`, `"Bit"`},
+		// Instances are references.
+		{animalClasses + `let a = new Animal("A"); let b = a; b.name = "B"; a.name`, `"B"`},
+		{animalClasses + `fn rename(a) { a.name = "Z" }` + "\n" + `let a = new Animal("A"); rename(a); a.name`, `"Z"`},
+		{animalClasses + `let a = new Animal("A"); a == a`, "true"},
+		{animalClasses + `new Animal("A") == new Animal("A")`, "false"},
+		// Method values keep their instance.
+		{animalClasses + `let a = new Animal("A"); let f = a.describe; a.name = "B"; f()`, `"I am B"`},
+		{"class Counter {\n\tconstructor() { this.n = 0 }\n\tadd(k int) { this.n += k; this }\n}\nnew Counter().add(2).add(3).n", "5"},
+		{"class A {\n\tconstructor() { this.fs = [fn() { this.x }] ; this.x = 1 }\n}\nnew A().fs[0]()", "1"},
+		// A field can shadow a method.
+		{animalClasses + `let a = new Animal("A"); a.speak = fn() { "quiet" }; a.speak()`, `"quiet"`},
+		// A class is a type of its instances and those of its subclasses.
+		{animalClasses + `var a Animal = new Dog("Rex", 2); a.name`, `"Rex"`},
+		{animalClasses + `var a Animal; a`, "null"},
+		{animalClasses + `fn name(a Animal) string { a.name }` + "\n" + `name(new Animal("A"))`, `"A"`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestInstanceof(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{animalClasses + `new Dog("R", 0) instanceof Dog`, true},
+		{animalClasses + `new Dog("R", 0) instanceof Animal`, true},
+		{animalClasses + `new Animal("A") instanceof Dog`, false},
+		{animalClasses + `1 instanceof Animal`, false},
+		{pointType + "Point{} instanceof Point", true},
+		{pointType + "type Other struct { X int; Y int }\nPoint{} instanceof Other", false},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(t, tt.input), tt.expected)
+	}
+}
+
+func TestTypeof(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"typeof 1", "int"},
+		{"typeof 1.5", "float"},
+		{"typeof 2i", "complex"},
+		{`typeof "a"`, "string"},
+		{"typeof 'a'", "rune"},
+		{"typeof true", "boolean"},
+		{"typeof null", "null"},
+		{"typeof [1]", "array"},
+		{"typeof {}", "hash"},
+		{"typeof (1..3)", "range"},
+		{"typeof fn() {}", "function"},
+		{"typeof len", "function"},
+		{pointType + "typeof Point{}", "Point"},
+		{pointType + "typeof Point", "type"},
+		{animalClasses + `typeof new Dog("R", 0)`, "Dog"},
+		{animalClasses + "typeof Dog", "class"},
+		{"typeof typeof 1", "string"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		str, ok := evaluated.(*object.String)
+		if !ok {
+			t.Errorf("%q: object is not String. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if str.Value != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, str.Value)
+		}
+	}
+}
+
+func TestClassErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"class A extends B {}", "identifier not found: B"},
+		{"let B = 1\nclass A extends B {}", "B is not a class"},
+		{"let A = 1; new A()", "A is not a class"},
+		{"new Missing()", "identifier not found: Missing"},
+		{"class A {}\nnew A(1)", "wrong number of arguments to A: want=0, got=1"},
+		{animalClasses + "new Animal()", "wrong number of arguments to Animal.constructor: want=1, got=0"},
+		{animalClasses + `new Animal("A").fly()`, "Animal has no field or method fly"},
+		{"this", "cannot use this outside a class method"},
+		{"fn f() { this.x }\nf()", "cannot use this outside a class method"},
+		{"super()", "cannot use super outside a class method"},
+		{"class A { constructor() { super() } }\nnew A()", "cannot use super in a class that extends nothing"},
+		{"class A { f() { super.f() } }\nnew A().f()", "cannot use super in a class that extends nothing"},
+		{"class A {}\nclass B extends A { f() { super.g() } }\nnew B().f()", "A has no method g"},
+		{"class A { constructor() { 1 + true } }\nnew A()", "type mismatch: INTEGER + BOOLEAN"},
+		{"1 instanceof 2", "right operand of instanceof must be a class or struct type, got INTEGER"},
+		{animalClasses + `var d Dog = new Animal("A")`, "cannot use Animal{name: \"A\"} (INSTANCE) as Dog value in variable declaration"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expectedMessage, errObj.Message)
+		}
+	}
+}
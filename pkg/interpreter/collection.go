@@ -0,0 +1,201 @@
+package interpreter
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/object"
+)
+
+func evalArrayLiteral(node *ast.ArrayLiteral, env *Environment) object.Object {
+	elements := evalExpressions(node.Elements, env)
+	if len(elements) == 1 && isError(elements[0]) {
+		return elements[0]
+	}
+
+	if node.Type != nil {
+		for i, element := range elements {
+			converted, err := convertTo(node.Type.Element, element, env, "array literal")
+			if err != nil {
+				return err
+			}
+			elements[i] = converted
+		}
+	}
+	for i, element := range elements {
+		elements[i] = copyValue(element)
+	}
+	return &object.Array{Elements: elements}
+}
+
+func evalHashLiteral(node *ast.HashLiteral, env *Environment) object.Object {
+	hash := object.NewHash()
+
+	for _, pair := range node.Pairs {
+		key := Eval(pair.Key, env)
+		if isError(key) {
+			return key
+		}
+		value := Eval(pair.Value, env)
+		if isError(value) {
+			return value
+		}
+		if err := hash.Set(key, copyValue(value)); err != nil {
+			return object.NewError("%s", err)
+		}
+	}
+	return hash
+}
+
+func evalIndexExpression(left, index object.Object) object.Object {
+	switch left := left.(type) {
+	case *object.Array:
+		i, err := checkIndex(index, left.Length())
+		if err != nil {
+			return err
+		}
+		return left.Elements[i]
+
+	case *object.String:
+		runes := []rune(left.Value)
+		i, err := checkIndex(index, len(runes))
+		if err != nil {
+			return err
+		}
+		return &object.Rune{Value: runes[i]}
+
+	case *object.Range:
+		i, err := checkIndex(index, left.Length())
+		if err != nil {
+			return err
+		}
+		element, _ := left.Get(i)
+		return element
+
+	case *object.Hash:
+		if _, ok := index.(object.Hashable); !ok {
+			return object.NewError("unusable as hash key: %s", index.Type())
+		}
+		if value, ok := left.Get(index); ok {
+			return value
+		}
+		return object.NULL
+
+	default:
+		return object.NewError("index operator not supported: %s", left.Type())
+	}
+}
+
+// checkIndex converts index to an int in the range [0, length). A negative
+// index counts back from the end, so -1 is the last element.
+func checkIndex(index object.Object, length int) (int, *object.Error) {
+	integer, ok := index.(*object.Integer)
+	if !ok {
+		return 0, object.NewError("index must be INTEGER, got %s", index.Type())
+	}
+	i := integer.Value
+	if i < 0 {
+		i += int64(length)
+	}
+	if i < 0 || i >= int64(length) {
+		return 0, object.NewError("index out of range [%d] with length %d", integer.Value, length)
+	}
+	return int(i), nil
+}
+
+// evalSliceExpression evaluates a[low:high] and a[low:high:max]. Slicing an
+// array shares its elements, as in Go, so assigning through the slice
+// changes the original; max limits the capacity of the result. Strings are
+// sliced by character and ranges give a smaller range.
+func evalSliceExpression(node *ast.SliceExpression, env *Environment) object.Object {
+	left := Eval(node.Left, env)
+	if isError(left) {
+		return left
+	}
+
+	var length, capacity int
+	switch left := left.(type) {
+	case *object.Array:
+		length, capacity = len(left.Elements), cap(left.Elements)
+	case *object.String:
+		length = left.Length()
+	case *object.Range:
+		length = left.Length()
+	default:
+		return object.NewError("cannot slice %s", left.Type())
+	}
+	if node.Max != nil && left.Type() != object.ARRAY_OBJ {
+		return object.NewError("3-index slice of %s", left.Type())
+	}
+
+	low, high, max, err := evalSliceBounds(node, env, length, capacity)
+	if err != nil {
+		return err
+	}
+
+	switch left := left.(type) {
+	case *object.Array:
+		if node.Max == nil {
+			return &object.Array{Elements: left.Elements[low:high]}
+		}
+		return &object.Array{Elements: left.Elements[low:high:max]}
+	case *object.String:
+		sub, _ := left.Substring(low, high)
+		return sub
+	default:
+		r := left.(*object.Range)
+		return &object.Range{Start: r.Start + int64(low), End: r.Start + int64(high)}
+	}
+}
+
+// evalSliceBounds evaluates the indexes of a slice. Omitted indexes default
+// to 0, length and capacity, and negative ones count back from length. The
+// result must satisfy 0 <= low <= high <= max, with high <= length in the
+// two-index form and max <= capacity in the three-index form.
+func evalSliceBounds(node *ast.SliceExpression, env *Environment, length, capacity int) (int, int, int, object.Object) {
+	exprs := [3]ast.Expression{node.Low, node.High, node.Max}
+	written := [3]int64{0, int64(length), int64(length)}
+	if node.Max != nil {
+		written[2] = int64(capacity)
+	}
+
+	var bounds [3]int64
+	for i, expr := range exprs {
+		if expr != nil {
+			index := Eval(expr, env)
+			if isError(index) {
+				return 0, 0, 0, index
+			}
+			integer, ok := index.(*object.Integer)
+			if !ok {
+				return 0, 0, 0, object.NewError("slice index must be INTEGER, got %s", index.Type())
+			}
+			written[i] = integer.Value
+		}
+		bounds[i] = written[i]
+		if bounds[i] < 0 {
+			bounds[i] += int64(length)
+		}
+	}
+	low, high, max := bounds[0], bounds[1], bounds[2]
+
+	switch {
+	case node.Max != nil && (max < 0 || max > int64(capacity)):
+		return 0, 0, 0, object.NewError("slice bounds out of range [::%d] with capacity %d", written[2], capacity)
+	case node.Max != nil && (high < 0 || high > max):
+		return 0, 0, 0, object.NewError("slice bounds out of range [:%d:%d]", written[1], written[2])
+	case high < 0 || high > int64(length) && node.Max == nil:
+		return 0, 0, 0, object.NewError("slice bounds out of range [:%d] with length %d", written[1], length)
+	case low < 0 || low > high:
+		return 0, 0, 0, object.NewError("slice bounds out of range [%d:%d]", written[0], written[1])
+	}
+	return int(low), int(high), int(max), nil
+}
+
+// evalRangeExpression builds the range start..end or start..<end.
+func evalRangeExpression(operator string, start, end object.Object) object.Object {
+	startInt, ok := start.(*object.Integer)
+	endInt, ok2 := end.(*object.Integer)
+	if !ok || !ok2 {
+		return object.NewError("range bounds must be INTEGER, got %s %s %s", start.Type(), operator, end.Type())
+	}
+	return &object.Range{Start: startInt.Value, End: endInt.Value, Inclusive: operator == ".."}
+}
@@ -0,0 +1,184 @@
+package interpreter
+
+import (
+	"testing"
+
+	"kisumu/pkg/object"
+)
+
+func TestCollectionExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[1, 2 * 2, 3 + 3]", "[1, 4, 6]"},
+		{"[]", "[]"},
+		{`[1, "two", [3]]`, `[1, "two", [3]]`},
+		{"[]float{1, 2.5}", "[1.0, 2.5]"},
+		{"[][]int{[]int{1}, []int{}}", "[[1], []]"},
+		{`let two = "two"; {"one": 10 - 9, two: 1 + 1, 3: 3, true: 4}`, `{"one": 1, "two": 2, 3: 3, true: 4}`},
+		{"{}", "{}"},
+		{"[1, 2, 3][0]", "1"},
+		{"[1, 2, 3][1 + 1]", "3"},
+		{"let i = 0; [1][i]", "1"},
+		{"let grid = [[1, 2], [3, 4]]; grid[1][0]", "3"},
+		{`"héllo"[1]`, "'é'"},
+		{`{"foo": 5}["foo"]`, "5"},
+		{`{"foo": 5}["bar"]`, "null"},
+		{"{true: 5}[1 > 0]", "5"},
+		{"let fns = [fn(x) { x * 2 }]; fns[0](21)", "42"},
+		{"let a = [1, 2, 3]; a[1] = 20; a", "[1, 20, 3]"},
+		{`let h = {"a": 1}; h["a"] = 2; h["b"] = 3; h`, `{"a": 2, "b": 3}`},
+		{"let m = [[0, 0], [0, 0]]; m[1][0] = 5; m", "[[0, 0], [5, 0]]"},
+		{"let a = [1]; let b = a; b[0] = 9; a[0]", "9"},
+		{"let sum = 0\nforeach x in [1, 2, 3] { sum = sum + x }\nsum", "6"},
+		{"[1, 2, 3][-1]", "3"},
+		{`"héllo"[-4]`, "'é'"},
+		{"let a = [1, 2, 3]; a[-1] = 30; a", "[1, 2, 30]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestCollectionErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"[1, 2, 3][3]", "index out of range [3] with length 3"},
+		{"[1, 2, 3][-4]", "index out of range [-4] with length 3"},
+		{`"abc"[5]`, "index out of range [5] with length 3"},
+		{`[1]["0"]`, "index must be INTEGER, got STRING"},
+		{"5[0]", "index operator not supported: INTEGER"},
+		{`{"a": 1}[fn(x) { x }]`, "unusable as hash key: FUNCTION"},
+		{"{[1]: 2}", "unusable as hash key: ARRAY"},
+		{`[]int{1, "2"}`, `cannot use "2" (STRING) as int value in array literal`},
+		{"[]float{true}", "cannot use true (BOOLEAN) as float value in array literal"},
+		{"[][]int{1}", "cannot use 1 (INTEGER) as []int value in array literal"},
+		{"let a = [1]; a[1] = 2", "index out of range [1] with length 1"},
+		{`let s = "abc"; s[0] = 'x'`, "cannot assign to an element of a STRING: strings are immutable"},
+		{"let n = 5; n[0] = 1", "index assignment not supported: INTEGER"},
+		{"[missing]", "identifier not found: missing"},
+		{"(0..<3)[3]", "index out of range [3] with length 3"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestSliceExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[1, 2, 3, 4, 5][1:3]", "[2, 3]"},
+		{"[1, 2, 3][:2]", "[1, 2]"},
+		{"[1, 2, 3][1:]", "[2, 3]"},
+		{"[1, 2, 3][:]", "[1, 2, 3]"},
+		{"[1, 2, 3][3:]", "[]"},
+		{"[1, 2, 3, 4][-2:]", "[3, 4]"},
+		{"[1, 2, 3, 4][1:-1]", "[2, 3]"},
+		{"[1, 2, 3, 4][0:2:3]", "[1, 2]"},
+		{"let a = [1, 2, 3]; let b = a[1:]; b[0] = 20; a", "[1, 20, 3]"},
+		{"let a = [1, 2, 3, 4]; len(a[1:2:3])", "1"},
+		{`"héllo"[1:4]`, `"éll"`},
+		{`"héllo"[-3:]`, `"llo"`},
+		{`""[:]`, `""`},
+		{"(1..10)[2:5]", "3..<6"},
+		{"(0..<5)[-2:]", "3..<5"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestRanges(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1..5", "1..5"},
+		{"0..<3", "0..<3"},
+		{"let n = 4; 1..n + 1", "1..5"},
+		{"len(1..5)", "5"},
+		{"len(0..<3)", "3"},
+		{"len(3..3)", "1"},
+		{"len(3..<3)", "0"},
+		{"len(5..1)", "0"},
+		{"(1..5)[0]", "1"},
+		{"(1..5)[-1]", "5"},
+		{"let sum = 0\nforeach x in 1..10 { sum = sum + x }\nsum", "55"},
+		{"let sum = 0\nforeach i, x in 10..<13 { sum = sum + i * x }\nsum", "35"},
+		{"let n = 0\nforeach x in 5..1 { n = n + 1 }\nn", "0"},
+		{"let last = 0\nforeach i in 0..<1000000000000 { if i == 3 { break }\nlast = i }\nlast", "2"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestSliceAndRangeErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"[1, 2, 3][:4]", "slice bounds out of range [:4] with length 3"},
+		{"[1, 2, 3][2:1]", "slice bounds out of range [2:1]"},
+		{"[1, 2, 3][-4:]", "slice bounds out of range [-4:3]"},
+		{"[1, 2, 3][0:1:4]", "slice bounds out of range [::4] with capacity 3"},
+		{"[1, 2, 3][0:3:2]", "slice bounds out of range [:3:2]"},
+		{`[1, 2, 3]["a":]`, "slice index must be INTEGER, got STRING"},
+		{`"abc"[0:1:2]`, "3-index slice of STRING"},
+		{"5[1:]", "cannot slice INTEGER"},
+		{`{"a": 1}[:]`, "cannot slice HASH"},
+		{"1..2.5", "range bounds must be INTEGER, got INTEGER .. FLOAT"},
+		{`"a"..<"z"`, "range bounds must be INTEGER, got STRING ..< STRING"},
+		{"let r = 1..3; r[0] = 5", "index assignment not supported: RANGE"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expectedMessage, errObj.Message)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package interpreter
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/object"
+)
+
+// isTruthy reports whether obj counts as true in a condition: everything but
+// false and null does.
+func isTruthy(obj object.Object) bool {
+	switch obj {
+	case object.NULL, object.FALSE:
+		return false
+	default:
+		return true
+	}
+}
+
+func evalIfExpression(ie *ast.IfExpression, env *Environment) object.Object {
+	condition := Eval(ie.Condition, env)
+	if isError(condition) {
+		return condition
+	}
+
+	var result object.Object
+	if isTruthy(condition) {
+		result = Eval(ie.Consequence, env)
+	} else if ie.Alternative != nil {
+		result = Eval(ie.Alternative, env)
+	}
+	if result == nil {
+		// The chosen block was empty or ended in a declaration.
+		return object.NULL
+	}
+	return result
+}
+
+// evalTernaryExpression evaluates only the branch that cond selects.
+func evalTernaryExpression(te *ast.TernaryExpression, env *Environment) object.Object {
+	condition := Eval(te.Condition, env)
+	if isError(condition) {
+		return condition
+	}
+	if isTruthy(condition) {
+		return Eval(te.Consequence, env)
+	}
+	return Eval(te.Alternative, env)
+}
+
+// evalLogicalExpression evaluates a && b and a || b, also spelled and and
+// or, given the value of a. The right operand is only evaluated when a does
+// not decide the result. The result is always a boolean.
+func evalLogicalExpression(node *ast.InfixExpression, left object.Object, env *Environment) object.Object {
+	if isTruthy(left) == (node.Token.Type == lexer.OR) {
+		return object.NativeBool(isTruthy(left))
+	}
+	right := Eval(node.Right, env)
+	if isError(right) {
+		return right
+	}
+	return object.NativeBool(isTruthy(right))
+}
+
+func evalLabeledStatement(ls *ast.LabeledStatement, env *Environment) object.Object {
+	label := ls.Label.Value
+	switch loop := ls.Statement.(type) {
+	case *ast.WhileStatement:
+		return evalWhileStatement(loop, env, label)
+	case *ast.ForStatement:
+		return evalForStatement(loop, env, label)
+	case *ast.ForeachStatement:
+		return evalForeachStatement(loop, env, label)
+	default:
+		return Eval(ls.Statement, env)
+	}
+}
+
+// loopControl decides what a loop labeled label does after its body
+// evaluated to result. It reports whether the loop stops and, if so, what the
+// loop statement evaluates to: nil when the loop itself was broken out of,
+// otherwise the return, error or branch to an outer loop to pass on.
+func loopControl(result object.Object, label string) (stop bool, value object.Object) {
+	switch result := result.(type) {
+	case *object.Break:
+		if result.Label == "" || result.Label == label {
+			return true, nil
+		}
+		return true, result
+	case *object.Continue:
+		if result.Label == "" || result.Label == label {
+			return false, nil
+		}
+		return true, result
+	case *object.ReturnValue, *object.Error:
+		return true, result
+	}
+	return false, nil
+}
+
+func evalWhileStatement(ws *ast.WhileStatement, env *Environment, label string) object.Object {
+	for {
+		condition := Eval(ws.Condition, env)
+		if isError(condition) {
+			return condition
+		}
+		if !isTruthy(condition) {
+			return nil
+		}
+
+		if stop, value := loopControl(Eval(ws.Body, env), label); stop {
+			return value
+		}
+	}
+}
+
+// evalForStatement runs a for loop. The variables its init statement
+// declares are scoped to the loop.
+func evalForStatement(fs *ast.ForStatement, env *Environment, label string) object.Object {
+	env = NewEnclosedEnvironment(env)
+	if fs.Init != nil {
+		if init := Eval(fs.Init, env); isError(init) {
+			return init
+		}
+	}
+
+	for {
+		if fs.Condition != nil {
+			condition := Eval(fs.Condition, env)
+			if isError(condition) {
+				return condition
+			}
+			if !isTruthy(condition) {
+				return nil
+			}
+		}
+
+		if stop, value := loopControl(Eval(fs.Body, env), label); stop {
+			return value
+		}
+
+		if fs.Post != nil {
+			if post := Eval(fs.Post, env); isError(post) {
+				return post
+			}
+		}
+	}
+}
+
+// evalForeachStatement runs the body once for every element of an array,
+// character of a string or pair of a hash, binding the loop variables
+// before each run. The collection is the one seen when the loop starts:
+// elements appended by the body are not visited.
+func evalForeachStatement(fs *ast.ForeachStatement, env *Environment, label string) object.Object {
+	iterable := Eval(fs.Iterable, env)
+	if isError(iterable) {
+		return iterable
+	}
+
+	// each binds the loop variables in a scope of their own and runs the
+	// body, reporting whether the loop stops.
+	var result object.Object
+	each := func(index, value object.Object) bool {
+		iterEnv := NewEnclosedEnvironment(env)
+		if fs.Index != nil {
+			iterEnv.Set(fs.Index.Value, index)
+		}
+		iterEnv.Set(fs.Value.Value, value)
+
+		var stop bool
+		stop, result = loopControl(Eval(fs.Body, iterEnv), label)
+		return stop
+	}
+
+	switch iterable := iterable.(type) {
+	case *object.Array:
+		for i, element := range iterable.Elements {
+			if each(&object.Integer{Value: int64(i)}, element) {
+				break
+			}
+		}
+	case *object.String:
+		i := 0
+		for _, ch := range iterable.Value {
+			if each(&object.Integer{Value: int64(i)}, &object.Rune{Value: ch}) {
+				break
+			}
+			i++
+		}
+	case *object.Range:
+		for i, n := range iterable.All() {
+			if each(&object.Integer{Value: int64(i)}, &object.Integer{Value: n}) {
+				break
+			}
+		}
+	case *object.Hash:
+		for _, pair := range iterable.Pairs() {
+			value := pair.Value
+			if fs.Index == nil {
+				value = pair.Key // a single variable ranges over the keys
+			}
+			if each(pair.Key, value) {
+				break
+			}
+		}
+	default:
+		return object.NewError("cannot iterate over %s", iterable.Type())
+	}
+	return result
+}
@@ -0,0 +1,262 @@
+package interpreter
+
+import (
+	"testing"
+
+	"kisumu/pkg/object"
+)
+
+func TestBooleanAndNullLiterals(t *testing.T) {
+	testBooleanObject(t, testEval(t, "true"), true)
+	testBooleanObject(t, testEval(t, "false == false"), true)
+	testBooleanObject(t, testEval(t, "!true"), false)
+	if evaluated := testEval(t, "null"); evaluated != object.NULL {
+		t.Errorf("expected NULL, got %T (%+v)", evaluated, evaluated)
+	}
+}
+
+func TestIfElseExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"if true { 10 }", 10},
+		{"if false { 10 }", nil},
+		{"if null { 10 } else { 20 }", 20},
+		{"if 0 { 10 }", 10},
+		{"if (1 < 2) { 10 }", 10},
+		{"if 1 > 2 { 10 } else { 20 }", 20},
+		{"if 1 > 2 { 10 } else if 2 > 1 { 15 } else { 20 }", 15},
+		{"if 1 > 2 { 10 } else if 2 > 3 { 15 } else { 20 }", 20},
+		{"let x = if 1 < 2 { 1 } else { 2 }\nx * 10", 10},
+		{"fn sign(n) {\n\tif n < 0 {\n\t\treturn -1\n\t} else if n > 0 {\n\t\treturn 1\n\t}\n\t0\n}\nsign(-5) + sign(3) * 10", 9},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if expected, ok := tt.expected.(int); ok {
+			testIntegerObject(t, evaluated, int64(expected))
+		} else if evaluated != object.NULL {
+			t.Errorf("%q: object is not NULL. got=%T (%+v)", tt.input, evaluated, evaluated)
+		}
+	}
+}
+
+func TestIfEndingInDeclaration(t *testing.T) {
+	tests := []string{
+		"let x = if true { let y = 2 }\nx",
+		"x := if true { var y int } else { 1 }\nx",
+		"let x = if false { 1 } else { const c = 1 }\nx",
+		"let x = if true {}\nx",
+		"fn f() { if true { let y = 2 } }\nf()",
+	}
+
+	for _, input := range tests {
+		if evaluated := testEval(t, input); evaluated != object.NULL {
+			t.Errorf("%q: object is not NULL. got=%T (%+v)", input, evaluated, evaluated)
+		}
+	}
+
+	// Using the value must be a runtime error, not a crash.
+	evaluated := testEval(t, "let x = if true { let y = 2 }\nx + 1")
+	if errObj, ok := evaluated.(*object.Error); !ok || errObj.Message != "type mismatch: NULL + INTEGER" {
+		t.Errorf("expected a type mismatch error, got %T (%+v)", evaluated, evaluated)
+	}
+}
+
+func TestRecursion(t *testing.T) {
+	input := `
+fn fib(n) {
+	if n < 2 { return n }
+	fib(n - 1) + fib(n - 2)
+}
+fib(15)
+`
+	testIntegerObject(t, testEval(t, input), 610)
+}
+
+func TestLoops(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let i = 0\nwhile i < 5 { i = i + 1 }\ni", 5},
+		{"let i = 0\nwhile false { let i = 1 }\ni", 0},
+		{"let sum = 0\nfor let i = 1; i < 5; i = i + 1 { sum = sum + i }\nsum", 10},
+		{"let n = 0\nfor n < 3 { n = n + 1 }\nn", 3},
+		{"let n = 0\nfor { n = n + 1\n if n == 7 { break } }\nn", 7},
+		{"let sum = 0\nfor let i = 0; i < 10; i = i + 1 {\n\tif i == 3 { continue }\n\tif i == 5 { break }\n\tsum = sum + i\n}\nsum", 7},
+		{"let i = 0\nfor ; i < 4; { i = i + 2 }\ni", 4},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(t, tt.input), tt.expected)
+	}
+}
+
+func TestForeach(t *testing.T) {
+	env := NewEnvironment()
+	env.Set("xs", &object.Array{Elements: []object.Object{
+		&object.Integer{Value: 10}, &object.Integer{Value: 20}, &object.Integer{Value: 30},
+	}})
+	h := object.NewHash()
+	h.Set(&object.String{Value: "a"}, &object.Integer{Value: 1})
+	h.Set(&object.String{Value: "b"}, &object.Integer{Value: 2})
+	env.Set("h", h)
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let sum = 0\nforeach x in xs { sum = sum + x }\nsum", 60},
+		{"let sum = 0\nforeach i, x in xs { sum = sum + i * x }\nsum", 80},
+		{"let s = \"\"\nforeach k in h { s = s + k }\ns", "ab"},
+		{"let sum = 0\nforeach k, v in h { sum = sum + v }\nsum", 3},
+		{"let s = \"\"\nforeach i, ch in \"héllo\" { if i == 1 { continue }\ns = s + ch }\ns", "hllo"},
+		{"let n = 0\nforeach ch in \"日本語\" { n = n + 1 }\nn", 3},
+		{"foreach x in 5 { }", "cannot iterate over INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := evalInput(t, tt.input, env)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			switch result := evaluated.(type) {
+			case *object.String:
+				if result.Value != expected {
+					t.Errorf("%q: expected %q, got %q", tt.input, expected, result.Value)
+				}
+			case *object.Error:
+				if result.Message != expected {
+					t.Errorf("%q: expected error %q, got %q", tt.input, expected, result.Message)
+				}
+			default:
+				t.Errorf("%q: unexpected result %T (%+v)", tt.input, evaluated, evaluated)
+			}
+		}
+	}
+}
+
+func TestBlockScope(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let y = 1\nif true { let y = 2 }\ny", "1"},
+		{"let y = 1\nif false { } else { let y = 2 }\ny", "1"},
+		{"let y = 1\nif true { y = 2 }\ny", "2"},
+		{"let i = 5\nfor i := 0; i < 3; i++ { }\ni", "5"},
+		{"let x = 10\nforeach x in [1, 2] { }\nx", "10"},
+		{"let k = 1\nforeach k, v in {\"a\": 2} { }\nk", "1"},
+		{"const x = 1\nforeach x in [2] { }\nx", "1"},
+		{"let n = 0\nwhile n < 2 { let m = n; n = m + 1 }\nn", "2"},
+		{"let fs = [null, null]\nforeach i in 0..<2 { fs[i] = fn() { i } }\nfs[0]() + fs[1]()", "1"},
+		{"let n = 0\nfor i := 0; i < 3; i++ { let sq = i * i; n = n + sq }\nn", "5"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestBlockScopedNamesDoNotLeak(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"if true { let y = 2 }\ny", "identifier not found: y"},
+		{"if false { } else { var y = 2 }\ny", "identifier not found: y"},
+		{"for i := 0; i < 3; i++ { }\ni", "identifier not found: i"},
+		{"for let i = 0; i < 3; i = i + 1 { let sq = i }\nsq", "identifier not found: sq"},
+		{"foreach x in [1, 2] { }\nx", "identifier not found: x"},
+		{"foreach i, x in [1, 2] { }\ni", "identifier not found: i"},
+		{"let n = 0\nwhile n < 1 { n = n + 1; const c = 1 }\nc", "identifier not found: c"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("%q: wrong error message. expected=%q, got=%q", tt.input, tt.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestLabeledBranches(t *testing.T) {
+	input := `
+let count = 0
+outer: for let i = 0; i < 5; i = i + 1 {
+	inner: foreach ch in "abc" {
+		if ch == 'b' { continue outer }
+		if i == 3 { break outer }
+		count = count + 1
+	}
+}
+count
+`
+	testIntegerObject(t, testEval(t, input), 3)
+
+	input = `
+let n = 0
+loop: while true {
+	for { n = n + 1
+		if n > 2 { break loop }
+		continue loop }
+}
+n
+`
+	testIntegerObject(t, testEval(t, input), 3)
+}
+
+func TestReturnFromLoop(t *testing.T) {
+	input := `
+fn find(s, target) {
+	foreach i, ch in s {
+		while true {
+			if ch == target { return i }
+			break
+		}
+	}
+	return -1
+}
+find("kisumu", 's') * 10 + find("kisumu", 'z')
+`
+	testIntegerObject(t, testEval(t, input), 19)
+}
+
+func TestLoopErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"while missing { }", "identifier not found: missing"},
+		{"for let i = 0; i < 3; i = i + x { }", "identifier not found: x"},
+		{"for { 1 / 0 }", "division by zero"},
+		{"if -true { 1 }", "unknown operator: -BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expectedMessage, errObj.Message)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package interpreter
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/object"
+)
+
+// evalValueSpecs evaluates the specs of a var or const statement in order,
+// binding each name and its declared type, if any, with define. A spec with a type converts its value to
+// that type, or starts from the type's zero value when it has no value;
+// context names the kind of declaration in conversion errors.
+func evalValueSpecs(specs []*ast.ValueSpec, env *Environment, context string,
+	define func(string, ast.Expression, object.Object) *object.Error) object.Object {
+	for _, spec := range specs {
+		var val object.Object
+		if spec.Value == nil {
+			val = zeroValue(spec.Type, env)
+		} else {
+			val = Eval(spec.Value, env)
+			if isError(val) {
+				return val
+			}
+		}
+
+		if spec.Type != nil {
+			converted, err := convertTo(spec.Type, val, env, context)
+			if err != nil {
+				return err
+			}
+			val = converted
+		}
+
+		if err := define(spec.Name.Value, spec.Type, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
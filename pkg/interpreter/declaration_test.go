@@ -0,0 +1,105 @@
+package interpreter
+
+import (
+	"testing"
+
+	"kisumu/pkg/object"
+)
+
+func TestDeclarations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"var age int = 1; age", "1"},
+		{`var name string = "Monkey"; name`, `"Monkey"`},
+		{"var result = 10 * (10 / 2); result", "50"},
+		{"var f float = 2; f", "2.0"},
+		{"var c complex = 1; c", "(1+0i)"},
+		{"var xs []float = [1, 2.5]; xs", "[1.0, 2.5]"},
+		{"var n int; n", "0"},
+		{"var f float; f", "0.0"},
+		{`var s string; s`, `""`},
+		{"var ok boolean; ok", "false"},
+		{"var ok bool; ok", "false"},
+		{"let boolean = true; var b boolean = boolean; b", "true"},
+		{"var xs []int; xs", "[]"},
+		{"var anything any; anything", "null"},
+		{"array := []int{1, 2, 3}; array", "[1, 2, 3]"},
+		{"x := 1; x = x + 1; x", "2"},
+		{"x := 1\nfn f() { x := 2; x }\nf() + x", "3"},
+		{"x := 1\nif true { x := 2 }\nx", "1"},
+		{"for i := 0; i < 2; i++ { const c = i }\n1", "1"},
+		{"foreach x in [1, 2] { const c = x }\n1", "1"},
+		{"let sum = 0\nforeach x in [1, 2, 3] { const c = x * 10; sum += c }\nsum", "60"},
+		{"let n = 0\nwhile n < 3 { const step = 1; n += step }\nn", "3"},
+		{"var (\n\ta = 1\n\tb = a + 1\n)\nb", "2"},
+		{"const pi = 3.14; pi", "3.14"},
+		{"const (\n\tx int = 10\n\ty = x * 2\n)\ny", "20"},
+		{"const xs = [1]; xs[0] = 2; xs", "[2]"},
+		{"const n = 1\nfn f() { const n = 2; n }\nf() + n", "3"},
+		{"let sum = 0\nfor i := 1; i < 4; i++ { sum = sum + i }\nsum", "6"},
+		{"var f float = 1; f = 2; f", "2.0"},
+		{"var f float = 1; f += 1; f", "2.0"},
+		{"var xs []float; xs = [1, 2]; xs", "[1.0, 2.0]"},
+		{"var f float = 1\nif true { f = 3 }\nf", "3.0"},
+		{"var x int = 1; var x = \"s\"; x = true; x", "true"},
+		{"var anything any = 1; anything = \"s\"; anything", `"s"`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestDeclarationErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{`var age int = "one"`, `cannot use "one" (STRING) as int value in variable declaration`},
+		{"var xs []int = [1, 2.5]", "cannot use [1, 2.5] (ARRAY) as []int value in variable declaration"},
+		{"var f float = 1i", "cannot use (0+1i) (COMPLEX) as float value in variable declaration"},
+		{`const n int = "x"`, `cannot use "x" (STRING) as int value in constant declaration`},
+		{"var x = missing", "identifier not found: missing"},
+		{"var x strnig = 5", "unknown type strnig"},
+		{"var x strnig", "unknown type strnig"},
+		{"var xs []strnig = []", "unknown type strnig"},
+		{"let n = 1; var x n = 5", "n is not a type"},
+		{"fn f(x intt) { x }\nf(1)", "unknown type intt"},
+		{"fn f() strnig { 1 }\nf()", "unknown type strnig"},
+		{"type P struct { X strnig }\nP{X: 1}", "unknown type strnig"},
+		{`var x int = 1; x = "s"`, `cannot use "s" (STRING) as int value in assignment`},
+		{"var x int = 1; x += 0.5", "cannot use 1.5 (FLOAT) as int value in assignment"},
+		{"var x int = 1\nfn f() { x = true }\nf()", "cannot use true (BOOLEAN) as int value in assignment"},
+		{"var xs []int; xs = [1, 2.5]", "cannot use [1, 2.5] (ARRAY) as []int value in assignment"},
+		{"const pi = 3; let pi = 4", "cannot redeclare constant pi"},
+		{"const pi = 3; var pi = 4", "cannot redeclare constant pi"},
+		{"const pi = 3; pi := 4", "no new variables on left side of :="},
+		{"x := 1; x := 2", "no new variables on left side of :="},
+		{"let x = 1\nx := 2", "no new variables on left side of :="},
+		{"fn f(x) { x := 2 }\nf(1)", "no new variables on left side of :="},
+		{"const pi = 3; const pi = 4", "cannot redeclare constant pi"},
+		{"const f = 1; fn f() { }", "cannot redeclare constant f"},
+		{"const (\n\ta = 1\n\ta = 2\n)", "cannot redeclare constant a"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expectedMessage, errObj.Message)
+		}
+	}
+}
@@ -1 +1,578 @@
-package interpreter
\ No newline at end of file
+package interpreter
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/object"
+)
+
+// Eval walks the given node and returns the value it evaluates to.
+// Runtime failures are reported as *object.Error values rather than Go errors,
+// so callers should check the result with isError before using it.
+func Eval(node ast.Node, env *Environment) object.Object {
+	switch node := node.(type) {
+
+	// Statements
+	case *ast.Program:
+		return evalProgram(node, env)
+
+	case *ast.ExpressionStatement:
+		return Eval(node.Expression, env)
+
+	case *ast.LetStatement:
+		val := Eval(node.Value, env)
+		if isError(val) {
+			return val
+		}
+		if val == nil {
+			val = object.NULL
+		}
+		if err := env.Define(node.Name.Value, val); err != nil {
+			return err
+		}
+		return nil
+
+	case *ast.VarStatement:
+		return evalValueSpecs(node.Specs, env, "variable declaration", env.DefineTyped)
+
+	case *ast.ConstStatement:
+		// A constant is never assigned, so its type is not kept.
+		return evalValueSpecs(node.Specs, env, "constant declaration",
+			func(name string, _ ast.Expression, val object.Object) *object.Error {
+				return env.DefineConst(name, val)
+			})
+
+	case *ast.ShortVarDeclaration:
+		// As in Go, := must declare a new name; assignment changes an old one.
+		if env.declaredHere(node.Name.Value) {
+			return object.NewError("no new variables on left side of :=")
+		}
+		val := Eval(node.Value, env)
+		if isError(val) {
+			return val
+		}
+		if val == nil {
+			val = object.NULL
+		}
+		if err := env.Define(node.Name.Value, val); err != nil {
+			return err
+		}
+		return nil
+
+	case *ast.BlockStatement:
+		return evalBlockStatement(node, NewEnclosedEnvironment(env))
+
+	case *ast.TypeStatement:
+		return evalTypeStatement(node, env)
+
+	case *ast.MethodStatement:
+		return evalMethodStatement(node, env)
+
+	case *ast.ClassStatement:
+		return evalClassStatement(node, env)
+
+	case *ast.FunctionStatement:
+		function := newFunction(node.Name.Value, node.Function, env)
+		if err := env.Define(node.Name.Value, function); err != nil {
+			return err
+		}
+		return nil
+
+	case *ast.WhileStatement:
+		return evalWhileStatement(node, env, "")
+
+	case *ast.ForStatement:
+		return evalForStatement(node, env, "")
+
+	case *ast.ForeachStatement:
+		return evalForeachStatement(node, env, "")
+
+	case *ast.LabeledStatement:
+		return evalLabeledStatement(node, env)
+
+	case *ast.BranchStatement:
+		label := ""
+		if node.Label != nil {
+			label = node.Label.Value
+		}
+		if node.Token.Type == lexer.BREAK {
+			return &object.Break{Label: label}
+		}
+		return &object.Continue{Label: label}
+
+	case *ast.AssignStatement:
+		return evalAssignStatement(node, env)
+
+	case *ast.IncDecStatement:
+		return evalIncDecStatement(node, env)
+
+	case *ast.ReturnStatement:
+		if node.ReturnValue == nil {
+			return &object.ReturnValue{Value: object.NULL}
+		}
+		val := Eval(node.ReturnValue, env)
+		if isError(val) {
+			return val
+		}
+		return &object.ReturnValue{Value: val}
+
+	// Expressions
+	case *ast.IntegerLiteral:
+		return &object.Integer{Value: node.Value}
+
+	case *ast.FloatLiteral:
+		return &object.Float{Value: node.Value}
+
+	case *ast.ImaginaryLiteral:
+		return &object.Complex{Value: node.Value}
+
+	case *ast.StringLiteral:
+		return &object.String{Value: node.Value}
+
+	case *ast.RuneLiteral:
+		return &object.Rune{Value: node.Value}
+
+	case *ast.Boolean:
+		return object.NativeBool(node.Value)
+
+	case *ast.NullLiteral:
+		return object.NULL
+
+	case *ast.Identifier:
+		return evalIdentifier(node, env)
+
+	case *ast.IfExpression:
+		return evalIfExpression(node, env)
+
+	case *ast.TernaryExpression:
+		return evalTernaryExpression(node, env)
+
+	case *ast.ArrayLiteral:
+		return evalArrayLiteral(node, env)
+
+	case *ast.HashLiteral:
+		return evalHashLiteral(node, env)
+
+	case *ast.StructType:
+		return &StructType{Node: node, Env: env}
+
+	case *ast.CompositeLiteral:
+		return evalCompositeLiteral(node, env)
+
+	case *ast.IndexExpression:
+		left := Eval(node.Left, env)
+		if isError(left) {
+			return left
+		}
+		index := Eval(node.Index, env)
+		if isError(index) {
+			return index
+		}
+		return evalIndexExpression(left, index)
+
+	case *ast.SelectorExpression:
+		if _, ok := node.X.(*ast.SuperExpression); ok {
+			return evalSuperSelector(node.Sel.Value, env)
+		}
+		x := Eval(node.X, env)
+		if isError(x) {
+			return x
+		}
+		return evalSelector(x, node.Sel.Value)
+
+	case *ast.SliceExpression:
+		return evalSliceExpression(node, env)
+
+	case *ast.RangeExpression:
+		start := Eval(node.Start, env)
+		if isError(start) {
+			return start
+		}
+		end := Eval(node.End, env)
+		if isError(end) {
+			return end
+		}
+		return evalRangeExpression(node.Operator, start, end)
+
+	case *ast.FunctionLiteral:
+		return newFunction("", node, env)
+
+	case *ast.NewExpression:
+		return evalNewExpression(node, env)
+
+	case *ast.ThisExpression:
+		return evalThisExpression(env)
+
+	case *ast.SuperExpression:
+		return object.NewError("super must be called or selected from")
+
+	case *ast.CallExpression:
+		if _, ok := node.Function.(*ast.SuperExpression); ok {
+			return evalSuperCall(node, env)
+		}
+		function := Eval(node.Function, env)
+		if isError(function) {
+			return function
+		}
+		args := evalExpressions(node.Arguments, env)
+		if len(args) == 1 && isError(args[0]) {
+			return args[0]
+		}
+		return applyFunction(function, args)
+
+	case *ast.PrefixExpression:
+		right := Eval(node.Right, env)
+		if isError(right) {
+			return right
+		}
+		return evalPrefixExpression(node.Operator, right)
+
+	case *ast.InfixExpression:
+		left := Eval(node.Left, env)
+		if isError(left) {
+			return left
+		}
+		if node.Token.Type == lexer.AND || node.Token.Type == lexer.OR {
+			return evalLogicalExpression(node, left, env)
+		}
+		right := Eval(node.Right, env)
+		if isError(right) {
+			return right
+		}
+		return evalInfixExpression(node.Operator, left, right)
+	}
+
+	return nil
+}
+
+// evalProgram evaluates every statement in order, stopping early on a
+// return statement or a runtime error.
+func evalProgram(program *ast.Program, env *Environment) object.Object {
+	var result object.Object
+
+	for _, statement := range program.Statements {
+		result = Eval(statement, env)
+
+		switch result := result.(type) {
+		case *object.ReturnValue:
+			return result.Value
+		case *object.Error:
+			return result
+		}
+	}
+
+	return result
+}
+
+// evalBlockStatement evaluates the statements of a block in order in env,
+// which holds the names the block declares. Unlike evalProgram it leaves a
+// ReturnValue wrapped, so that the return unwinds every enclosing block up to
+// the function being called; break and continue unwind up to their loop in
+// the same way.
+func evalBlockStatement(block *ast.BlockStatement, env *Environment) object.Object {
+	var result object.Object
+
+	for _, statement := range block.Statements {
+		result = Eval(statement, env)
+
+		if result != nil {
+			switch result.Type() {
+			case object.RETURN_VALUE_OBJ, object.ERROR_OBJ, object.BREAK_OBJ, object.CONTINUE_OBJ:
+				return result
+			}
+		}
+	}
+
+	if result == nil {
+		return object.NULL
+	}
+	return result
+}
+
+// evalExpressions evaluates exps from left to right. If one fails, the
+// result holds only its error.
+func evalExpressions(exps []ast.Expression, env *Environment) []object.Object {
+	result := make([]object.Object, 0, len(exps))
+
+	for _, e := range exps {
+		evaluated := Eval(e, env)
+		if isError(evaluated) {
+			return []object.Object{evaluated}
+		}
+		result = append(result, evaluated)
+	}
+
+	return result
+}
+
+func evalIdentifier(node *ast.Identifier, env *Environment) object.Object {
+	if val, ok := env.Get(node.Value); ok {
+		return val
+	}
+	if builtin, ok := builtins[node.Value]; ok {
+		return builtin
+	}
+	return object.NewError("identifier not found: %s", node.Value)
+}
+
+func evalPrefixExpression(operator string, right object.Object) object.Object {
+	switch operator {
+	case "!":
+		return evalBangOperatorExpression(right)
+	case "-":
+		return evalMinusPrefixOperatorExpression(right)
+	case "^":
+		if right, ok := right.(*object.Integer); ok {
+			return &object.Integer{Value: ^right.Value}
+		}
+		return object.NewError("unknown operator: ^%s", right.Type())
+	case "typeof":
+		return &object.String{Value: typeOf(right)}
+	default:
+		return object.NewError("unknown operator: %s%s", operator, right.Type())
+	}
+}
+
+func evalBangOperatorExpression(right object.Object) object.Object {
+	switch right {
+	case object.TRUE:
+		return object.FALSE
+	case object.FALSE:
+		return object.TRUE
+	case object.NULL:
+		return object.TRUE
+	default:
+		return object.FALSE
+	}
+}
+
+func evalMinusPrefixOperatorExpression(right object.Object) object.Object {
+	switch right := right.(type) {
+	case *object.Integer:
+		return &object.Integer{Value: -right.Value}
+	case *object.Float:
+		return &object.Float{Value: -right.Value}
+	case *object.Complex:
+		return &object.Complex{Value: -right.Value}
+	default:
+		return object.NewError("unknown operator: -%s", right.Type())
+	}
+}
+
+func evalInfixExpression(operator string, left, right object.Object) object.Object {
+	if operator == "instanceof" {
+		return evalInstanceofExpression(left, right)
+	}
+	left, right = convertRuneOperands(left, right)
+
+	switch {
+	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
+		return evalIntegerInfixExpression(operator, left, right)
+	case isNumber(left) && isNumber(right):
+		return evalNumberInfixExpression(operator, left, right)
+	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
+		return evalStringInfixExpression(operator, left, right)
+	case left.Type() == object.STRUCT_OBJ && right.Type() == object.STRUCT_OBJ:
+		return evalStructInfixExpression(operator, left.(*Struct), right.(*Struct))
+	case operator == "==":
+		return object.NativeBool(left == right)
+	case operator == "!=":
+		return object.NativeBool(left != right)
+	case left.Type() != right.Type():
+		return object.NewError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
+	default:
+		return object.NewError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+func evalIntegerInfixExpression(operator string, left, right object.Object) object.Object {
+	leftVal := left.(*object.Integer).Value
+	rightVal := right.(*object.Integer).Value
+
+	switch operator {
+	case "+":
+		return &object.Integer{Value: leftVal + rightVal}
+	case "-":
+		return &object.Integer{Value: leftVal - rightVal}
+	case "*":
+		return &object.Integer{Value: leftVal * rightVal}
+	case "/":
+		if rightVal == 0 {
+			return object.NewError("division by zero")
+		}
+		return &object.Integer{Value: leftVal / rightVal}
+	case "%":
+		if rightVal == 0 {
+			return object.NewError("division by zero")
+		}
+		return &object.Integer{Value: leftVal % rightVal}
+	case "&":
+		return &object.Integer{Value: leftVal & rightVal}
+	case "|":
+		return &object.Integer{Value: leftVal | rightVal}
+	case "^":
+		return &object.Integer{Value: leftVal ^ rightVal}
+	case "&^":
+		return &object.Integer{Value: leftVal &^ rightVal}
+	case "<<", ">>":
+		if rightVal < 0 {
+			return object.NewError("negative shift amount")
+		}
+		if operator == "<<" {
+			return &object.Integer{Value: leftVal << uint64(rightVal)}
+		}
+		return &object.Integer{Value: leftVal >> uint64(rightVal)}
+	case "<":
+		return object.NativeBool(leftVal < rightVal)
+	case ">":
+		return object.NativeBool(leftVal > rightVal)
+	case "<=":
+		return object.NativeBool(leftVal <= rightVal)
+	case ">=":
+		return object.NativeBool(leftVal >= rightVal)
+	case "==":
+		return object.NativeBool(leftVal == rightVal)
+	case "!=":
+		return object.NativeBool(leftVal != rightVal)
+	default:
+		return object.NewError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// evalNumberInfixExpression handles arithmetic on mixed number types. The
+// operands are promoted to the wider of the two types: integers to floats
+// and floats to complex numbers.
+func evalNumberInfixExpression(operator string, left, right object.Object) object.Object {
+	if left.Type() == object.COMPLEX_OBJ || right.Type() == object.COMPLEX_OBJ {
+		leftVal, rightVal := toComplex(left), toComplex(right)
+
+		switch operator {
+		case "+":
+			return &object.Complex{Value: leftVal + rightVal}
+		case "-":
+			return &object.Complex{Value: leftVal - rightVal}
+		case "*":
+			return &object.Complex{Value: leftVal * rightVal}
+		case "/":
+			if rightVal == 0 {
+				return object.NewError("division by zero")
+			}
+			return &object.Complex{Value: leftVal / rightVal}
+		case "==":
+			return object.NativeBool(leftVal == rightVal)
+		case "!=":
+			return object.NativeBool(leftVal != rightVal)
+		default:
+			return object.NewError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+		}
+	}
+
+	leftVal, rightVal := toFloat(left), toFloat(right)
+
+	switch operator {
+	case "+":
+		return &object.Float{Value: leftVal + rightVal}
+	case "-":
+		return &object.Float{Value: leftVal - rightVal}
+	case "*":
+		return &object.Float{Value: leftVal * rightVal}
+	case "/":
+		if rightVal == 0 {
+			return object.NewError("division by zero")
+		}
+		return &object.Float{Value: leftVal / rightVal}
+	case "<":
+		return object.NativeBool(leftVal < rightVal)
+	case ">":
+		return object.NativeBool(leftVal > rightVal)
+	case "<=":
+		return object.NativeBool(leftVal <= rightVal)
+	case ">=":
+		return object.NativeBool(leftVal >= rightVal)
+	case "==":
+		return object.NativeBool(leftVal == rightVal)
+	case "!=":
+		return object.NativeBool(leftVal != rightVal)
+	default:
+		return object.NewError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+func isNumber(obj object.Object) bool {
+	switch obj.Type() {
+	case object.INTEGER_OBJ, object.FLOAT_OBJ, object.COMPLEX_OBJ:
+		return true
+	}
+	return false
+}
+
+func toFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.Float:
+		return obj.Value
+	}
+	return 0
+}
+
+func toComplex(obj object.Object) complex128 {
+	if c, ok := obj.(*object.Complex); ok {
+		return c.Value
+	}
+	return complex(toFloat(obj), 0)
+}
+
+// convertRuneOperands applies the conversions a rune goes through when it
+// meets another value in an infix expression: next to a string it becomes a
+// one-character string, and next to a rune or integer it becomes its integer
+// code point.
+func convertRuneOperands(left, right object.Object) (object.Object, object.Object) {
+	leftRune, leftIsRune := left.(*object.Rune)
+	rightRune, rightIsRune := right.(*object.Rune)
+
+	switch {
+	case leftIsRune && right.Type() == object.STRING_OBJ:
+		return leftRune.String(), right
+	case rightIsRune && left.Type() == object.STRING_OBJ:
+		return left, rightRune.String()
+	case leftIsRune && rightIsRune:
+		return leftRune.Integer(), rightRune.Integer()
+	case leftIsRune && right.Type() == object.INTEGER_OBJ:
+		return leftRune.Integer(), right
+	case rightIsRune && left.Type() == object.INTEGER_OBJ:
+		return left, rightRune.Integer()
+	}
+	return left, right
+}
+
+func evalStringInfixExpression(operator string, left, right object.Object) object.Object {
+	leftVal := left.(*object.String)
+	rightVal := right.(*object.String)
+
+	switch operator {
+	case "+":
+		return leftVal.Concat(rightVal)
+	case "==":
+		return object.NativeBool(leftVal.Value == rightVal.Value)
+	case "!=":
+		return object.NativeBool(leftVal.Value != rightVal.Value)
+	case "<":
+		return object.NativeBool(leftVal.Value < rightVal.Value)
+	case ">":
+		return object.NativeBool(leftVal.Value > rightVal.Value)
+	case "<=":
+		return object.NativeBool(leftVal.Value <= rightVal.Value)
+	case ">=":
+		return object.NativeBool(leftVal.Value >= rightVal.Value)
+	default:
+		return object.NewError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+func isError(obj object.Object) bool {
+	if obj != nil {
+		return obj.Type() == object.ERROR_OBJ
+	}
+	return false
+}
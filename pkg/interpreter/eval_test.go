@@ -0,0 +1,378 @@
+package interpreter
+
+import (
+	"testing"
+
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/object"
+	"kisumu/pkg/parser"
+)
+
+func testEval(t *testing.T, input string) object.Object {
+	t.Helper()
+	l := lexer.Tokenize(input)
+	p := parser.NewParser(l)
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors for %q: %v", input, p.Errors())
+	}
+	return Eval(program, NewEnvironment())
+}
+
+func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
+	t.Helper()
+	result, ok := obj.(*object.Integer)
+	if !ok {
+		t.Errorf("object is not Integer. got=%T (%+v)", obj, obj)
+		return false
+	}
+	if result.Value != expected {
+		t.Errorf("object has wrong value. got=%d, want=%d", result.Value, expected)
+		return false
+	}
+	return true
+}
+
+func testBooleanObject(t *testing.T, obj object.Object, expected bool) bool {
+	t.Helper()
+	result, ok := obj.(*object.Boolean)
+	if !ok {
+		t.Errorf("object is not Boolean. got=%T (%+v)", obj, obj)
+		return false
+	}
+	if result.Value != expected {
+		t.Errorf("object has wrong value. got=%t, want=%t", result.Value, expected)
+		return false
+	}
+	return true
+}
+
+func TestEvalIntegerExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"5;", 5},
+		{"-10;", -10},
+		{"5 + 5 + 5 + 5 - 10;", 10},
+		{"2 * 2 * 2 * 2 * 2;", 32},
+		{"-50 + 100 + -50;", 0},
+		{"20 + 2 * -10;", 0},
+		{"50 / 2 * 2 + 10;", 60},
+		{"3 * 3 * 3 + 10;", 37},
+		{"17 % 5", 2},
+		{"-17 % 5", -2},
+		{"2 + 10 % 4 * 3", 8},
+		{"12 & 10", 8},
+		{"12 | 3", 15},
+		{"12 ^ 10", 6},
+		{"12 &^ 10", 4},
+		{"1 << 10", 1024},
+		{"-16 >> 2", -4},
+		{"1 << 64", 0},
+		{"^0", -1},
+		{"1 | 2 ^ 3 & 6", 1},
+		{"'a' % 32", 1},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(t, tt.input), tt.expected)
+	}
+}
+
+func TestEvalComparisonExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"1 < 2;", true},
+		{"1 > 2;", false},
+		{"1 == 1;", true},
+		{"1 != 1;", false},
+		{"1 < 2 == 2 > 1;", true},
+		{"!5;", false},
+		{"!!5;", true},
+		{"1 <= 1", true},
+		{"2 <= 1", false},
+		{"1 >= 2", false},
+		{"2.5 >= 2", true},
+		{"1.5 <= 1", false},
+		{`"abc" < "abd"`, true},
+		{`"b" >= "abc"`, true},
+		{`"a" > "a"`, false},
+		{"'a' <= 'b'", true},
+		{"true && true", true},
+		{"true && false", false},
+		{"false || true", true},
+		{"null || false", false},
+		{"0 && \"\"", true},
+		{"true and false or true", true},
+		{"false or false", false},
+		{"1 < 2 && 2 < 3 || false", true},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(t, tt.input), tt.expected)
+	}
+}
+
+func TestLetStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let a = 5; a;", 5},
+		{"let a = 5 * 5; a;", 25},
+		{"let a = 5; let b = a; b;", 5},
+		{"let a = 5; let b = a; let c = a + b + 5; c;", 15},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(t, tt.input), tt.expected)
+	}
+}
+
+func TestReturnStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"return 10;", 10},
+		{"return 10; 9;", 10},
+		{"return 2 * 5; 9;", 10},
+		{"9; return 2 * 5; 9;", 10},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(t, tt.input), tt.expected)
+	}
+}
+
+func TestErrorHandling(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"foobar;", "identifier not found: foobar"},
+		{"let b = 1 < 2; -b;", "unknown operator: -BOOLEAN"},
+		{"let b = 1 < 2; b + 5;", "type mismatch: BOOLEAN + INTEGER"},
+		{"let b = 1 < 2; b + b;", "unknown operator: BOOLEAN + BOOLEAN"},
+		{"5 / 0;", "division by zero"},
+		{"let x = y; 5;", "identifier not found: y"},
+		{`"Hello" - "World";`, "unknown operator: STRING - STRING"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestStringExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"Hello World!";`, "Hello World!"},
+		{`let name = "World"; "Hello " + name + "!";`, "Hello World!"},
+		{`"a" == "a";`, true},
+		{`"a" != "a";`, false},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		switch expected := tt.expected.(type) {
+		case string:
+			str, ok := evaluated.(*object.String)
+			if !ok {
+				t.Errorf("object is not String. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			if str.Value != expected {
+				t.Errorf("String has wrong value. got=%q, want=%q", str.Value, expected)
+			}
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		}
+	}
+}
+
+func TestNumericExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"3.14;", "3.14"},
+		{"-2.5;", "-2.5"},
+		{"0x1F + 0b1;", "32"},
+		{"1_000 * 2;", "2000"},
+		{"1.5 + 1;", "2.5"},
+		{"1 / 2.0;", "0.5"},
+		{"2.0 * 2;", "4.0"},
+		{"1e3;", "1000.0"},
+		{"2.5i;", "(0+2.5i)"},
+		{"1 + 2i;", "(1+2i)"},
+		{"2i * 2i;", "(-4+0i)"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s evaluated to %s (%T), want %s", tt.input, evaluated.Inspect(), evaluated, tt.expected)
+		}
+	}
+
+	testBooleanObject(t, testEval(t, "1.5 > 1;"), true)
+	testBooleanObject(t, testEval(t, "2 == 2.0;"), true)
+	testBooleanObject(t, testEval(t, "1i == 1i;"), true)
+}
+
+func TestRuneExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`'a';`, `'a'`},
+		{`'\n';`, `'\n'`},
+		{`'a' + 0;`, "97"},
+		{`'b' - 'a';`, "1"},
+		{`"caf" + 'é';`, `"café"`},
+		{`'>' + " go";`, `"> go"`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s evaluated to %s (%T), want %s", tt.input, evaluated.Inspect(), evaluated, tt.expected)
+		}
+	}
+
+	testBooleanObject(t, testEval(t, `'a' < 'b';`), true)
+	testBooleanObject(t, testEval(t, `'a' == 'a';`), true)
+	testBooleanObject(t, testEval(t, `'a' == 97;`), true)
+	testBooleanObject(t, testEval(t, `'a' != 'b';`), true)
+}
+
+func TestProgramsWithComments(t *testing.T) {
+	input := `
+// the answer
+let answer = 6 * 7; /* everything */
+answer;
+`
+	testIntegerObject(t, testEval(t, input), 42)
+}
+
+func TestUnicodeIdentifiersAndStrings(t *testing.T) {
+	input := `let salamu = "Habari"; let jina = "Wanjikũ"; let ujumbe = salamu + ", " + jina; ujumbe;`
+
+	evaluated := testEval(t, input)
+	str, ok := evaluated.(*object.String)
+	if !ok {
+		t.Fatalf("object is not String. got=%T (%+v)", evaluated, evaluated)
+	}
+	if str.Value != "Habari, Wanjikũ" || str.Length() != 15 {
+		t.Errorf("wrong string. got=%q (length %d)", str.Value, str.Length())
+	}
+}
+
+func TestNewlineTerminatedProgram(t *testing.T) {
+	input := `let a = 5
+let b = a *
+	2
+return
+b`
+	evaluated := testEval(t, input)
+	if evaluated != object.NULL {
+		t.Fatalf("bare return should yield null, got %T (%+v)", evaluated, evaluated)
+	}
+
+	testIntegerObject(t, testEval(t, "let a = 5\nlet b = a *\n\t2\nb\n"), 10)
+}
+
+func TestShortCircuitEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"false && missing", "false"},
+		{"true || missing", "true"},
+		{"null and 1 / 0", "false"},
+		{"1 or 1 / 0", "true"},
+		{"calls := 0; fn f() { calls++; true }\nfalse && f(); true || f(); true && f(); false || f(); calls", "2"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestTernaryExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"true ? 1 : 2", "1"},
+		{"null ? 1 : 2", "2"},
+		{`x := -5; x < 0 ? "negative" : "positive"`, `"negative"`},
+		{"n := 15; n % 15 == 0 ? 0 : n % 5 == 0 ? 5 : n % 3 == 0 ? 3 : n", "0"},
+		{"n := 10; n % 15 == 0 ? 0 : n % 5 == 0 ? 5 : n % 3 == 0 ? 3 : n", "5"},
+		{"true ? 1 : missing", "1"},
+		{"false ? 1 / 0 : 2", "2"},
+		{"fn abs(x) { x < 0 ? -x : x }\n[abs(-3), abs(4)]", "[3, 4]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestOperatorErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"5 % 0", "division by zero"},
+		{"1 << -1", "negative shift amount"},
+		{"1.5 % 2", "unknown operator: FLOAT % INTEGER"},
+		{"1.5 & 1.0", "unknown operator: FLOAT & FLOAT"},
+		{`"a" | "b"`, "unknown operator: STRING | STRING"},
+		{"^1.5", "unknown operator: ^FLOAT"},
+		{"1i <= 2i", "unknown operator: COMPLEX <= COMPLEX"},
+		{"true && missing", "identifier not found: missing"},
+		{"missing ? 1 : 2", "identifier not found: missing"},
+		{"true <= false", "unknown operator: BOOLEAN <= BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expectedMessage, errObj.Message)
+		}
+	}
+}
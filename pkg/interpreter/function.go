@@ -0,0 +1,118 @@
+package interpreter
+
+import (
+	"bytes"
+	"strings"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/object"
+)
+
+// Function is a user-defined function together with the environment it was
+// defined in, which its body sees when called. This makes functions closures.
+type Function struct {
+	Name           string // empty for anonymous functions
+	Parameters     []*ast.Identifier
+	ParameterTypes []ast.Expression // nil if the parameters are untyped
+	Result         ast.Expression   // nil if the result is untyped
+	Body           *ast.BlockStatement
+	Env            *Environment
+}
+
+// newFunction creates the function lit evaluates to in env.
+func newFunction(name string, lit *ast.FunctionLiteral, env *Environment) *Function {
+	return &Function{
+		Name:           name,
+		Parameters:     lit.Parameters,
+		ParameterTypes: lit.ParameterTypes,
+		Result:         lit.Result,
+		Body:           lit.Body,
+		Env:            env,
+	}
+}
+
+func (f *Function) Type() object.ObjectType { return object.FUNCTION_OBJ }
+func (f *Function) Inspect() string {
+	var out bytes.Buffer
+
+	params := make([]string, 0, len(f.Parameters))
+	for i, p := range f.Parameters {
+		if f.ParameterTypes != nil && f.ParameterTypes[i] != nil {
+			params = append(params, p.String()+" "+f.ParameterTypes[i].String())
+			continue
+		}
+		params = append(params, p.String())
+	}
+
+	out.WriteString("fn")
+	if f.Name != "" {
+		out.WriteString(" " + f.Name)
+	}
+	out.WriteString("(")
+	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(") ")
+	if f.Result != nil {
+		out.WriteString(f.Result.String() + " ")
+	}
+	out.WriteString(f.Body.String())
+	return out.String()
+}
+
+// applyFunction calls fn with args and returns the result.
+func applyFunction(fn object.Object, args []object.Object) object.Object {
+	switch fn := fn.(type) {
+	case *Function:
+		if len(args) != len(fn.Parameters) {
+			return object.NewError("wrong number of arguments to %s: want=%d, got=%d",
+				fn.displayName(), len(fn.Parameters), len(args))
+		}
+		env := NewEnclosedEnvironment(fn.Env)
+		for i, param := range fn.Parameters {
+			arg := args[i]
+			if fn.ParameterTypes != nil {
+				converted, err := convertTo(fn.ParameterTypes[i], arg, fn.Env, "argument to "+fn.displayName())
+				if err != nil {
+					return err
+				}
+				arg = converted
+			}
+			env.Set(param.Value, arg)
+		}
+
+		// The body shares the scope of the parameters.
+		result := unwrapReturnValue(evalBlockStatement(fn.Body, env))
+		if fn.Result == nil || isError(result) {
+			return result
+		}
+		converted, err := convertTo(fn.Result, result, fn.Env, "return statement")
+		if err != nil {
+			return err
+		}
+		return converted
+
+	case *object.Builtin:
+		return fn.Fn(args...)
+
+	default:
+		return object.NewError("not a function: %s", fn.Type())
+	}
+}
+
+func (f *Function) displayName() string {
+	if f.Name == "" {
+		return "anonymous function"
+	}
+	return f.Name
+}
+
+// unwrapReturnValue stops a return statement in a function body from
+// returning from the caller as well.
+func unwrapReturnValue(obj object.Object) object.Object {
+	if returnValue, ok := obj.(*object.ReturnValue); ok {
+		return returnValue.Value
+	}
+	if obj == nil {
+		return object.NULL
+	}
+	return obj
+}
@@ -0,0 +1,181 @@
+package interpreter
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/object"
+	"kisumu/pkg/parser"
+)
+
+// evalInput is like testEval but evaluates in env.
+func evalInput(t *testing.T, input string, env *Environment) object.Object {
+	t.Helper()
+	p := parser.NewParser(lexer.Tokenize(input))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors for %q: %v", input, p.Errors())
+	}
+	return Eval(program, env)
+}
+
+func TestFunctionObject(t *testing.T) {
+	evaluated := testEval(t, "fn(x) { x + 2; };")
+
+	fn, ok := evaluated.(*Function)
+	if !ok {
+		t.Fatalf("object is not Function. got=%T (%+v)", evaluated, evaluated)
+	}
+	if len(fn.Parameters) != 1 || fn.Parameters[0].String() != "x" {
+		t.Fatalf("function has wrong parameters. Parameters=%+v", fn.Parameters)
+	}
+	if body := fn.Body.String(); body != "{ (x + 2) }" {
+		t.Fatalf("body is not %q. got=%q", "{ (x + 2) }", body)
+	}
+	if inspected := fn.Inspect(); inspected != "fn(x) { (x + 2) }" {
+		t.Errorf("fn.Inspect() wrong. got=%q", inspected)
+	}
+}
+
+func TestFunctionApplication(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let identity = fn(x) { x; }; identity(5);", 5},
+		{"let identity = fn(x) { return x; }; identity(5);", 5},
+		{"let double = fn(x) { x * 2; }; double(5);", 10},
+		{"let add = fn(x, y) { x + y; }; add(5, 5);", 10},
+		{"let add = fn(x, y) { x + y; }; add(5 + 5, add(5, 5));", 20},
+		{"fn(x) { x; }(5)", 5},
+		{"fn add(x, y) { x + y }\nadd(2, 3)", 5},
+		{"function sub(x, y) {\n\treturn x - y\n\t99\n}\nsub(9, 4)", 5},
+		{"fn early() { return 1; 2 }; early() + 10", 11},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(t, tt.input), tt.expected)
+	}
+}
+
+func TestReturnInsideFunctionDoesNotStopCaller(t *testing.T) {
+	input := `
+fn one() { return 1 }
+let x = one()
+return x + 1
+`
+	testIntegerObject(t, testEval(t, input), 2)
+}
+
+func TestEmptyFunctionReturnsNull(t *testing.T) {
+	if evaluated := testEval(t, "fn nothing() {}\nnothing()"); evaluated != object.NULL {
+		t.Errorf("expected NULL, got %T (%+v)", evaluated, evaluated)
+	}
+}
+
+func TestClosures(t *testing.T) {
+	input := `
+let newAdder = fn(x) {
+	fn(y) { x + y }
+}
+let addTwo = newAdder(2)
+addTwo(2)
+`
+	testIntegerObject(t, testEval(t, input), 4)
+}
+
+func TestHigherOrderFunctions(t *testing.T) {
+	input := `
+fn twice(f, x) { f(f(x)) }
+fn compose(f, g) { fn(x) { g(f(x)) } }
+let inc = fn(x) { x + 1 }
+let double = fn(x) { x * 2 }
+twice(compose(inc, double), 1)
+`
+	testIntegerObject(t, testEval(t, input), 10)
+}
+
+func TestFunctionsSeeLaterDefinitions(t *testing.T) {
+	input := `
+fn callsHelper() { helper() }
+fn helper() { 42 }
+callsHelper()
+`
+	testIntegerObject(t, testEval(t, input), 42)
+}
+
+func TestFunctionErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"fn f(x) { x }; f()", "wrong number of arguments to f: want=1, got=0"},
+		{"fn(x, y) { x }(1)", "wrong number of arguments to anonymous function: want=2, got=1"},
+		{"let x = 5; x(1)", "not a function: INTEGER"},
+		{"fn f(x) { x }; f(y)", "identifier not found: y"},
+		{"fn f() { 1 / 0; 5 }; f()", "division by zero"},
+		{"fn f() { missing }; f() + 1", "identifier not found: missing"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestBuiltinFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`len("")`, 0},
+		{`len("four")`, 4},
+		{`len("héllo")`, 5},
+		{`len(args)`, 2},
+		{`len(1)`, "argument to len not supported, got INTEGER"},
+		{`len("one", "two")`, "wrong number of arguments to len: want=1, got=2"},
+		{`let len = fn(x) { 7 }; len("a")`, 7},
+	}
+
+	for _, tt := range tests {
+		env := NewEnvironment()
+		env.Set("args", &object.Array{Elements: []object.Object{object.NULL, object.NULL}})
+		evaluated := evalInput(t, tt.input, env)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestPrintBuiltins(t *testing.T) {
+	var out bytes.Buffer
+	defer func(w io.Writer) { Output = w }(Output)
+	Output = &out
+
+	testEval(t, `print("a", 1, 'b'); println(""); println("x", 2.5, fn(y) { y })`)
+
+	expected := "a 1 b\nx 2.5 fn(y) { y }\n"
+	if out.String() != expected {
+		t.Errorf("expected output %q, got %q", expected, out.String())
+	}
+}
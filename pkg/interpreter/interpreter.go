@@ -1 +1,118 @@
-package interpreter
\ No newline at end of file
+package interpreter
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/object"
+)
+
+// Environment holds the name bindings visible to the code being evaluated.
+// An enclosed environment falls back to its outer environment for names it
+// does not define itself. Structs are copied as they are bound, so each
+// variable holds its own, and a variable declared with a type only ever
+// holds values of that type.
+type Environment struct {
+	store  map[string]object.Object
+	consts map[string]bool
+	types  map[string]ast.Expression
+	outer  *Environment
+}
+
+// NewEnvironment creates an empty top-level environment.
+func NewEnvironment() *Environment {
+	return &Environment{store: make(map[string]object.Object)}
+}
+
+// NewEnclosedEnvironment creates an empty environment nested inside outer.
+func NewEnclosedEnvironment(outer *Environment) *Environment {
+	env := NewEnvironment()
+	env.outer = outer
+	return env
+}
+
+// Get looks a name up in the environment and, failing that, in its outer environments.
+func (e *Environment) Get(name string) (object.Object, bool) {
+	obj, ok := e.store[name]
+	if !ok && e.outer != nil {
+		obj, ok = e.outer.Get(name)
+	}
+	return obj, ok
+}
+
+// declaredHere reports whether name is bound in this environment itself
+// rather than in an outer one.
+func (e *Environment) declaredHere(name string) bool {
+	_, ok := e.store[name]
+	return ok
+}
+
+// Set binds name to val in this environment and returns val.
+func (e *Environment) Set(name string, val object.Object) object.Object {
+	val = copyValue(val)
+	e.store[name] = val
+	delete(e.types, name)
+	return val
+}
+
+// Define binds name to val in this environment, failing if name is a
+// constant declared here. Declarations go through Define; Set is for names
+// the interpreter binds itself.
+func (e *Environment) Define(name string, val object.Object) *object.Error {
+	if e.consts[name] {
+		return object.NewError("cannot redeclare constant %s", name)
+	}
+	e.store[name] = copyValue(val)
+	delete(e.types, name)
+	return nil
+}
+
+// DefineTyped binds name to val like Define and records typ, resolved in
+// this environment, as the type of the variable, so that Assign converts the
+// values later stored in it. A nil typ declares an untyped variable.
+func (e *Environment) DefineTyped(name string, typ ast.Expression, val object.Object) *object.Error {
+	if err := e.Define(name, val); err != nil || typ == nil {
+		return err
+	}
+	if e.types == nil {
+		e.types = make(map[string]ast.Expression)
+	}
+	e.types[name] = typ
+	return nil
+}
+
+// DefineConst binds name to val like Define and makes the binding constant,
+// so name cannot be declared or assigned again in this environment. The
+// value itself is not frozen: the elements of a constant array can change.
+func (e *Environment) DefineConst(name string, val object.Object) *object.Error {
+	if err := e.Define(name, val); err != nil {
+		return err
+	}
+	if e.consts == nil {
+		e.consts = make(map[string]bool)
+	}
+	e.consts[name] = true
+	return nil
+}
+
+// Assign stores val in the existing variable name, in this environment or
+// the nearest outer one that declares it. It fails if name is not declared,
+// is a constant, or has a type val cannot be converted to.
+func (e *Environment) Assign(name string, val object.Object) *object.Error {
+	for env := e; env != nil; env = env.outer {
+		if _, ok := env.store[name]; !ok {
+			continue
+		}
+		if env.consts[name] {
+			return object.NewError("cannot assign to constant %s", name)
+		}
+		if typ, ok := env.types[name]; ok {
+			converted, err := convertTo(typ, val, env, "assignment")
+			if err != nil {
+				return err
+			}
+			val = converted
+		}
+		env.store[name] = copyValue(val)
+		return nil
+	}
+	return object.NewError("assignment to undeclared variable %s", name)
+}
@@ -0,0 +1,73 @@
+package interpreter
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/object"
+)
+
+// Method is a method of a struct type. A value receiver is bound to a copy
+// of the struct the method is selected on; a pointer receiver is bound to
+// the struct itself, so the method's changes to it are seen by the caller.
+type Method struct {
+	Receiver *ast.Identifier
+	Pointer  bool
+	Function *Function
+}
+
+// bind returns the method value for m selected on recv: a function whose
+// environment binds the receiver.
+func (m *Method) bind(name string, recv *Struct) *Function {
+	env := NewEnclosedEnvironment(m.Function.Env)
+	if m.Pointer {
+		// Bound directly rather than through Set, which would copy it.
+		env.store[m.Receiver.Value] = recv
+	} else {
+		env.Set(m.Receiver.Value, recv)
+	}
+
+	fn := *m.Function
+	fn.Name = recv.Def.Inspect() + "." + name
+	fn.Env = env
+	return &fn
+}
+
+// evalMethodStatement adds a method to the struct type named by its
+// receiver.
+func evalMethodStatement(node *ast.MethodStatement, env *Environment) object.Object {
+	typ := node.ReceiverType
+	pointer, isPointer := typ.(*ast.PointerType)
+	if isPointer {
+		typ = pointer.Base
+	}
+	typeName := typ.(*ast.Identifier).Value
+
+	val, ok := env.Get(typeName)
+	if !ok {
+		if isPredeclaredType(typeName) {
+			return object.NewError("cannot define new methods on non-local type %s", typeName)
+		}
+		return object.NewError("identifier not found: %s", typeName)
+	}
+	st, ok := val.(*StructType)
+	if !ok {
+		return object.NewError("%s is not a type", typeName)
+	}
+
+	name := node.Name.Value
+	if _, ok := st.Methods[name]; ok {
+		return object.NewError("method %s.%s already declared", st.Name, name)
+	}
+	if st.fieldIndex(name) >= 0 {
+		return object.NewError("field and method with the same name %s", name)
+	}
+
+	if st.Methods == nil {
+		st.Methods = make(map[string]*Method)
+	}
+	st.Methods[name] = &Method{
+		Receiver: node.Receiver,
+		Pointer:  isPointer,
+		Function: newFunction(name, node.Function, env),
+	}
+	return nil
+}
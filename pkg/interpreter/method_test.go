@@ -0,0 +1,150 @@
+package interpreter
+
+import (
+	"testing"
+
+	"kisumu/pkg/object"
+)
+
+func TestMethods(t *testing.T) {
+	dist := pointType + "fn (p Point) Sum() int { p.X + p.Y }\n"
+	move := pointType + "fn (p *Point) Move(dx int) { p.X += dx }\n"
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{dist + "let p = Point{1, 2}; p.Sum()", "3"},
+		{dist + "Point{3, 4}.Sum()", "7"},
+		{move + "let p = Point{1, 2}; p.Move(10); p", "Point{X: 11, Y: 2}"},
+		{move + "let p = Point{1, 2}; p.Move(1); p.Move(1); p.X", "3"},
+		// A value receiver works on a copy.
+		{pointType + "fn (p Point) Move(dx int) { p.X += dx; p }\nlet p = Point{1, 2}; let q = p.Move(10); [p, q]", "[Point{X: 1, Y: 2}, Point{X: 11, Y: 2}]"},
+		// Method values bind their receiver when they are selected.
+		{dist + "let p = Point{1, 2}; let f = p.Sum; p.X = 10; f()", "3"},
+		{move + "let p = Point{1, 2}; f := p.Move; f(5); p.X", "6"},
+		{dist + "let fs = [Point{1, 1}.Sum, Point{2, 2}.Sum]; fs[1]()", "4"},
+		// Methods of an embedded struct are promoted.
+		{move + "type Circle struct { Point; R int }\nlet c = Circle{}; c.Move(3); c.X", "3"},
+		{move + "type Line struct { A, B Point }\nlet l = Line{}; l.B.Move(2); l", "Line{A: Point{X: 0, Y: 0}, B: Point{X: 2, Y: 0}}"},
+		{pointType + "fn (p Point) Scale(n int) Point { Point{p.X * n, p.Y * n} }\nPoint{1, 2}.Scale(3).Scale(2)", "Point{X: 6, Y: 12}"},
+		{pointType + "fn (p Point) Half() float { p.X / 2 }\nPoint{4, 0}.Half()", "2.0"},
+		{pointType + "let p = Point{1, 2}\nfn (p Point) Sum() int { p.X + p.Y }\np.Sum()", "3"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestTypedFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn add(x, y int) int { x + y }\nadd(1, 2)", "3"},
+		{"fn half(x float) float { x / 2 }\nhalf(3)", "1.5"},
+		{"fn f() float { 1 }\nf()", "1.0"},
+		{"fn f(xs []float) { xs }\nf([1, 2])", "[1.0, 2.0]"},
+		{pointType + "fn origin() Point { Point{} }\norigin()", "Point{X: 0, Y: 0}"},
+		{"let f = fn(n int) string { \"\" + n }; f", "fn(n int) string { (\"\" + n) }"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestBuiltinMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"abc".length()`, "3"},
+		{`"héllo".substring(1, 3)`, `"él"`},
+		{`"ab".concat("cd")`, `"abcd"`},
+		{"[1, 2].first()", "1"},
+		{"[1, 2, 3].last()", "3"},
+		{"[].first()", "null"},
+		{"[1, 2].length()", "2"},
+		{"[1, 2].get(-1)", "2"},
+		{`let h = {"a": 1}; h.get("a")`, "1"},
+		{`let h = {"a": 1}; h.get("b")`, "null"},
+		{`let h = {}; h.set("a", 2); h`, `{"a": 2}`},
+		{`{"a": 1, "b": 2}.keys()`, `["a", "b"]`},
+		{`{"a": 1}.length()`, "1"},
+		{"let n = 2; n.add(3)", "5"},
+		{"let n = 2; n.subtract(0.5)", "1.5"},
+		{"(1).add(0.5)", "1.5"},
+		{"let x = 1.5; x.multiply(2)", "3.0"},
+		{"true.not()", "false"},
+		{"true.and(false)", "false"},
+		{"false.or(true)", "true"},
+		{"null.isNull()", "true"},
+		{"[1].isNotNull()", "true"},
+		{`let f = "abc".length; f()`, "3"},
+		{`"abc".length`, "builtin function length"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestMethodErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"fn (p Missing) F() {}", "identifier not found: Missing"},
+		{"fn (n int) F() {}", "cannot define new methods on non-local type int"},
+		{"let n = 1\nfn (p n) F() {}", "n is not a type"},
+		{pointType + "fn (p Point) F() {}\nfn (p *Point) F() {}", "method Point.F already declared"},
+		{pointType + "fn (p Point) X() {}", "field and method with the same name X"},
+		{pointType + "fn (p Point) F() {}\nlet p = Point{}; p.F = 1", "cannot assign to method Point.F"},
+		{pointType + "fn (p Point) F(n int) {}\nPoint{}.F()", "wrong number of arguments to Point.F: want=1, got=0"},
+		{pointType + "fn (p Point) F(n int) {}\nPoint{}.F(\"a\")", `cannot use "a" (STRING) as int value in argument to Point.F`},
+		{"fn f() int { \"a\" }\nf()", `cannot use "a" (STRING) as int value in return statement`},
+		{"fn f(x, y int) {}\nf(1, 1.5)", "cannot use 1.5 (FLOAT) as int value in argument to f"},
+		{"type A struct {}\ntype B struct {}\nfn (a A) F() {}\nfn (b B) F() {}\ntype C struct { A; B }\nC{}.F()", "ambiguous selector C.F"},
+		{`"abc".first()`, "STRING has no field or method first"},
+		{`"abc".length(1)`, "wrong number of arguments to length: want=0, got=1"},
+		{`"abc".substring(1, 5)`, "substring bounds out of range [1:5] with length 3"},
+		{`"abc".concat(1)`, "argument to concat must be STRING, got INTEGER"},
+		{"[1].get(3)", "index out of range [3] with length 1"},
+		{"let n = 1; n.add(true)", "argument to add not supported, got BOOLEAN"},
+		{"true.and(1)", "argument to and must be BOOLEAN, got INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expectedMessage, errObj.Message)
+		}
+	}
+}
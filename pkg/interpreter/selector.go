@@ -0,0 +1,70 @@
+package interpreter
+
+import "kisumu/pkg/object"
+
+// evalSelector evaluates x.name. The fields and methods of a struct include
+// those promoted from its embedded structs, and the methods of an instance
+// those inherited from its superclasses; values of the built-in types have
+// the methods in builtinMethods.
+func evalSelector(x object.Object, name string) object.Object {
+	if instance, ok := x.(*Instance); ok {
+		if val, ok := instance.get(name); ok {
+			return val
+		}
+	}
+	if s, ok := x.(*Struct); ok {
+		m, found := s.findMember(name)
+		switch {
+		case found == 1:
+			if method := m.method(name); method != nil {
+				return method.bind(name, m.holder)
+			}
+			return m.holder.Fields[m.index]
+		case found > 1:
+			return object.NewError("ambiguous selector %s.%s", s.Def.Inspect(), name)
+		}
+	}
+	if method := lookupBuiltinMethod(x.Type(), name); method != nil {
+		return &object.Builtin{Name: name, Fn: func(args ...object.Object) object.Object {
+			return method(x, args...)
+		}}
+	}
+	switch x := x.(type) {
+	case *Struct:
+		return object.NewError("%s has no field or method %s", x.Def.Inspect(), name)
+	case *Instance:
+		return object.NewError("%s has no field or method %s", x.Class.Name, name)
+	}
+	return object.NewError("%s has no field or method %s", x.Type(), name)
+}
+
+// setField stores val in the field name of x, converting it to the field's
+// type. Assigning to a field of an instance creates it if needed.
+func setField(x object.Object, name string, val object.Object) object.Object {
+	if instance, ok := x.(*Instance); ok {
+		instance.set(name, val)
+		return nil
+	}
+	s, ok := x.(*Struct)
+	if !ok {
+		return object.NewError("%s has no field %s", x.Type(), name)
+	}
+
+	m, found := s.findMember(name)
+	switch {
+	case found == 0:
+		return object.NewError("%s has no field %s", s.Def.Inspect(), name)
+	case found > 1:
+		return object.NewError("ambiguous selector %s.%s", s.Def.Inspect(), name)
+	case m.index < 0:
+		return object.NewError("cannot assign to method %s.%s", m.holder.Def.Inspect(), name)
+	}
+
+	field := m.holder.Def.Node.Fields[m.index]
+	converted, err := convertTo(field.Type, val, m.holder.Def.Env, "assignment")
+	if err != nil {
+		return err
+	}
+	m.holder.Fields[m.index] = copyValue(converted)
+	return nil
+}
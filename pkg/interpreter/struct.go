@@ -0,0 +1,295 @@
+package interpreter
+
+import (
+	"bytes"
+	"strings"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/object"
+)
+
+// StructType is a struct type, declared by a type statement or written out
+// in an anonymous struct literal. The types of its fields are resolved in
+// Env, the environment the type was declared in.
+type StructType struct {
+	Name    string // empty for an anonymous struct type
+	Node    *ast.StructType
+	Env     *Environment
+	Methods map[string]*Method // declared by method statements
+}
+
+func (st *StructType) Type() object.ObjectType { return object.TYPE_OBJ }
+func (st *StructType) Inspect() string {
+	if st.Name == "" {
+		return st.Node.String()
+	}
+	return st.Name
+}
+
+// fieldIndex returns the index of the field declared in st as name, or -1.
+// Promoted fields are not included.
+func (st *StructType) fieldIndex(name string) int {
+	for i, field := range st.Node.Fields {
+		if field.FieldName() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// zero returns the zero value of st: a struct whose fields hold the zero
+// values of their types.
+func (st *StructType) zero() *Struct {
+	fields := make([]object.Object, len(st.Node.Fields))
+	for i, field := range st.Node.Fields {
+		fields[i] = zeroValue(field.Type, st.Env)
+	}
+	return &Struct{Def: st, Fields: fields}
+}
+
+// contains reports whether a value of st holds a value of target, in a
+// field or in a field of a field. Fields of type []T hold a reference to
+// their elements and are not followed.
+func (st *StructType) contains(target *StructType, seen map[*StructType]bool) bool {
+	if seen[st] {
+		return false
+	}
+	seen[st] = true
+	for _, field := range st.Node.Fields {
+		fieldType, ok := resolveStructType(field.Type, st.Env)
+		if !ok {
+			continue
+		}
+		if sameType(fieldType, target) || fieldType.contains(target, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveStructType returns the struct type typ denotes in env, reporting
+// false if it is not a struct type.
+func resolveStructType(typ ast.Expression, env *Environment) (*StructType, bool) {
+	switch typ := typ.(type) {
+	case *ast.StructType:
+		return &StructType{Node: typ, Env: env}, true
+	case *ast.Identifier:
+		val, ok := env.Get(typ.Value)
+		if !ok {
+			return nil, false
+		}
+		st, ok := val.(*StructType)
+		return st, ok
+	}
+	return nil, false
+}
+
+// sameType reports whether a and b are the same struct type. A declared type
+// is only the same as itself; anonymous types are the same if they are
+// written the same way.
+func sameType(a, b *StructType) bool {
+	if a == b {
+		return true
+	}
+	return a.Name == "" && b.Name == "" && a.Node.String() == b.Node.String()
+}
+
+// Struct is a value of a struct type. Structs are values rather than
+// references: binding one to a name or storing it in a container or a field
+// stores a copy.
+type Struct struct {
+	Def    *StructType
+	Fields []object.Object // in the order the fields are declared in Def
+}
+
+func (s *Struct) Type() object.ObjectType { return object.STRUCT_OBJ }
+func (s *Struct) Inspect() string {
+	var out bytes.Buffer
+
+	fields := make([]string, len(s.Fields))
+	for i, field := range s.Def.Node.Fields {
+		fields[i] = field.FieldName() + ": " + s.Fields[i].Inspect()
+	}
+
+	out.WriteString(s.Def.Inspect())
+	out.WriteString("{")
+	out.WriteString(strings.Join(fields, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
+// Copy returns a copy of s. Struct fields are copied too, since they are
+// part of the value; the elements of arrays and hashes are shared.
+func (s *Struct) Copy() *Struct {
+	fields := make([]object.Object, len(s.Fields))
+	for i, field := range s.Fields {
+		fields[i] = copyValue(field)
+	}
+	return &Struct{Def: s.Def, Fields: fields}
+}
+
+// member is a field or method of a struct, found by selecting it.
+type member struct {
+	holder *Struct // the struct declaring the member
+	index  int     // the index of the field in holder, or -1 for a method
+}
+
+// method returns the method m denotes, or nil if it is a field.
+func (m member) method(name string) *Method {
+	if m.index >= 0 {
+		return nil
+	}
+	return m.holder.Def.Methods[name]
+}
+
+// findMember finds the field or method name of s. If s does not declare it,
+// its embedded structs are searched, breadth first, for a promoted one. It
+// returns the member and the number of members of that name at the
+// shallowest depth they were found at; more than one makes the selector
+// ambiguous.
+func (s *Struct) findMember(name string) (member, int) {
+	level := []*Struct{s}
+	for len(level) != 0 {
+		var found member
+		count := 0
+		var next []*Struct
+		for _, current := range level {
+			if i := current.Def.fieldIndex(name); i >= 0 {
+				found = member{holder: current, index: i}
+				count++
+			}
+			if _, ok := current.Def.Methods[name]; ok {
+				found = member{holder: current, index: -1}
+				count++
+			}
+			for i, field := range current.Def.Node.Fields {
+				if embedded, ok := current.Fields[i].(*Struct); ok && field.Name == nil {
+					next = append(next, embedded)
+				}
+			}
+		}
+		if count != 0 {
+			return found, count
+		}
+		level = next
+	}
+	return member{}, 0
+}
+
+// copyValue returns the value to store when val is bound or stored: a copy
+// of a struct, and val itself otherwise.
+func copyValue(val object.Object) object.Object {
+	if s, ok := val.(*Struct); ok {
+		return s.Copy()
+	}
+	return val
+}
+
+// evalTypeStatement binds the name of a struct type declaration. The name
+// is bound before the recursion check, since the fields may refer to it, and
+// the binding is undone if the type is invalid so that it cannot be used.
+func evalTypeStatement(node *ast.TypeStatement, env *Environment) object.Object {
+	st := &StructType{Name: node.Name.Value, Node: node.Type, Env: env}
+	previous, hadPrevious := env.store[st.Name]
+	previousType, wasTyped := env.types[st.Name]
+	if err := env.Define(st.Name, st); err != nil {
+		return err
+	}
+	if st.contains(st, make(map[*StructType]bool)) {
+		if hadPrevious {
+			env.store[st.Name] = previous
+			if wasTyped {
+				env.types[st.Name] = previousType
+			}
+		} else {
+			delete(env.store, st.Name)
+		}
+		return object.NewError("invalid recursive type %s", st.Name)
+	}
+	return nil
+}
+
+// evalCompositeLiteral evaluates Type{...}. Fields that are not given start
+// from the zero values of their types.
+func evalCompositeLiteral(node *ast.CompositeLiteral, env *Environment) object.Object {
+	typ := Eval(node.Type, env)
+	if isError(typ) {
+		return typ
+	}
+	st, ok := typ.(*StructType)
+	if !ok {
+		return object.NewError("%s is not a type", node.Type)
+	}
+	s := st.zero()
+
+	if len(node.Elements) == 0 {
+		return s
+	}
+	if _, keyed := node.Elements[0].(*ast.KeyValueExpression); !keyed {
+		if len(node.Elements) < len(s.Fields) {
+			return object.NewError("too few values in struct literal of type %s", st.Inspect())
+		}
+		if len(node.Elements) > len(s.Fields) {
+			return object.NewError("too many values in struct literal of type %s", st.Inspect())
+		}
+		for i, element := range node.Elements {
+			if err := initField(s, i, element, env); err != nil {
+				return err
+			}
+		}
+		return s
+	}
+
+	given := make(map[string]bool)
+	for _, element := range node.Elements {
+		kv := element.(*ast.KeyValueExpression)
+		name := kv.Key.(*ast.Identifier).Value
+		i := st.fieldIndex(name)
+		if i < 0 {
+			return object.NewError("unknown field %s in struct literal of type %s", name, st.Inspect())
+		}
+		if given[name] {
+			return object.NewError("duplicate field name %s in struct literal", name)
+		}
+		given[name] = true
+		if err := initField(s, i, kv.Value, env); err != nil {
+			return err
+		}
+	}
+	return s
+}
+
+// initField evaluates the value of field i in a struct literal.
+func initField(s *Struct, i int, value ast.Expression, env *Environment) object.Object {
+	val := Eval(value, env)
+	if isError(val) {
+		return val
+	}
+	field := s.Def.Node.Fields[i]
+	converted, err := convertTo(field.Type, val, s.Def.Env, "struct literal")
+	if err != nil {
+		return err
+	}
+	s.Fields[i] = copyValue(converted)
+	return nil
+}
+
+// evalStructInfixExpression compares two structs, which are equal if they
+// have the same type and their fields are equal.
+func evalStructInfixExpression(operator string, left, right *Struct) object.Object {
+	if operator != "==" && operator != "!=" {
+		return object.NewError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+	if !sameType(left.Def, right.Def) {
+		return object.NewError("type mismatch: %s %s %s", left.Def.Inspect(), operator, right.Def.Inspect())
+	}
+
+	equal := true
+	for i := range left.Fields {
+		if evalInfixExpression("==", left.Fields[i], right.Fields[i]) != object.TRUE {
+			equal = false
+			break
+		}
+	}
+	return object.NativeBool(equal == (operator == "=="))
+}
@@ -0,0 +1,195 @@
+package interpreter
+
+import (
+	"testing"
+
+	"kisumu/pkg/object"
+)
+
+const pointType = "type Point struct { X int; Y int }\n"
+
+func TestStructs(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{pointType + "Point{X: 1, Y: 2}", "Point{X: 1, Y: 2}"},
+		{pointType + "Point{1, 2}", "Point{X: 1, Y: 2}"},
+		{pointType + "Point{Y: 2}", "Point{X: 0, Y: 2}"},
+		{pointType + "Point{}", "Point{X: 0, Y: 0}"},
+		{pointType + "var p Point; p", "Point{X: 0, Y: 0}"},
+		{pointType + "Point", "Point"},
+		{pointType + "let p = Point{1, 2}; p.X + p.Y", "3"},
+		{pointType + "let p = Point{1, 2}; p.X = 5; p", "Point{X: 5, Y: 2}"},
+		{pointType + "let p = Point{1, 2}; p.X += 10; p.Y++; p", "Point{X: 11, Y: 3}"},
+		{pointType + "Point{1, 2}.Y", "2"},
+		{"type T struct { F float; S string; Xs []int; Ok boolean }\nT{F: 1}", `T{F: 1.0, S: "", Xs: [], Ok: false}`},
+		{"type Line struct { A, B Point }\n" + pointType + "Line{}", "Line{A: Point{X: 0, Y: 0}, B: Point{X: 0, Y: 0}}"},
+		{"type Line struct { A, B Point }\n" + pointType + "let l = Line{}; l.B.X = 4; l.B", "Point{X: 4, Y: 0}"},
+		{`let p = struct { Name string }{Name: "Ada"}; p.Name`, `"Ada"`},
+		{"type Box struct { In struct { N int } }\nlet b = Box{}; b.In.N = 1; b", "Box{In: struct { N int }{N: 1}}"},
+		{"type Box struct { In struct { N int } }\nBox{In: struct { N int }{N: 2}}.In.N", "2"},
+		{pointType + "var ps []Point = [Point{1, 2}]; ps[0].Y", "2"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestStructValueSemantics(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{pointType + "let p = Point{1, 2}; let q = p; q.X = 9; [p, q]", "[Point{X: 1, Y: 2}, Point{X: 9, Y: 2}]"},
+		{pointType + "var p = Point{1, 2}; var q Point; q = p; q.X = 9; p", "Point{X: 1, Y: 2}"},
+		{pointType + "fn move(p) { p.X = 100; p }\nlet a = Point{1, 2}; let b = move(a); [a, b]", "[Point{X: 1, Y: 2}, Point{X: 100, Y: 2}]"},
+		{pointType + "let p = Point{1, 2}; let ps = [p]; ps[0].X = 9; [p, ps[0]]", "[Point{X: 1, Y: 2}, Point{X: 9, Y: 2}]"},
+		{pointType + "let ps = [Point{}]; let q = ps[0]; q.X = 9; ps", "[Point{X: 0, Y: 0}]"},
+		{pointType + `let p = Point{}; let h = {"p": p}; p.X = 1; h["p"]`, "Point{X: 0, Y: 0}"},
+		{pointType + "foreach p in [Point{1, 2}] { p.X = 0 }\n1", "1"},
+		{"type Line struct { A Point }\n" + pointType + "let a = Point{1, 2}; let l = Line{A: a}; a.X = 9; l.A", "Point{X: 1, Y: 2}"},
+		{"type Line struct { A Point }\n" + pointType + "let l = Line{}; let m = l; m.A.X = 9; l.A.X", "0"},
+		// Arrays in a struct are shared by its copies, as slices are in Go.
+		{"type Bag struct { Items []int }\nlet b = Bag{Items: [1]}; let c = b; c.Items[0] = 2; b.Items", "[2]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestStructEquality(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{pointType + "Point{1, 2} == Point{1, 2}", true},
+		{pointType + "Point{1, 2} == Point{2, 1}", false},
+		{pointType + "Point{1, 2} != Point{1, 3}", true},
+		{pointType + "let p = Point{1, 2}; let q = p; q.Y = 3; p == q", false},
+		{pointType + "if (Point{}) == (Point{}) { true } else { false }", true},
+		{"type Line struct { A, B Point }\n" + pointType + "Line{B: Point{1, 1}} == Line{B: Point{1, 1}}", true},
+		{"struct { N int }{1} == struct { N int }{1}", true},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(t, tt.input), tt.expected)
+	}
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	circle := pointType + "type Circle struct { Point; R float }\n"
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{circle + "Circle{Point: Point{1, 2}, R: 3}", "Circle{Point: Point{X: 1, Y: 2}, R: 3.0}"},
+		{circle + "let c = Circle{Point{1, 2}, 3}; c.X", "1"},
+		{circle + "let c = Circle{}; c.Y = 5; c", "Circle{Point: Point{X: 0, Y: 5}, R: 0.0}"},
+		{circle + "let c = Circle{}; c.Point = Point{7, 8}; c.X", "7"},
+		{circle + "type Ring struct { Circle; X string }\nlet r = Ring{}; r.X", `""`},
+		{circle + "type Ring struct { Circle; Width int }\nlet r = Ring{}; r.Y = 4; r.Circle.Point", "Point{X: 0, Y: 4}"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			t.Errorf("%q: unexpected error %q", tt.input, errObj.Message)
+			continue
+		}
+		if got := evaluated.Inspect(); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestStructErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{pointType + `Point{X: "a"}`, `cannot use "a" (STRING) as int value in struct literal`},
+		{pointType + "Point{1}", "too few values in struct literal of type Point"},
+		{pointType + "Point{1, 2, 3}", "too many values in struct literal of type Point"},
+		{pointType + "Point{Z: 1}", "unknown field Z in struct literal of type Point"},
+		{pointType + "Point{X: 1, X: 2}", "duplicate field name X in struct literal"},
+		{pointType + "let p = Point{}; p.Z", "Point has no field or method Z"},
+		{pointType + "let p = Point{}; p.Z = 1", "Point has no field Z"},
+		{pointType + "let p = Point{}; p.X = 1.5", "cannot use 1.5 (FLOAT) as int value in assignment"},
+		{pointType + "var p Point = 1", "cannot use 1 (INTEGER) as Point value in variable declaration"},
+		{pointType + "type Other struct { X int; Y int }\nvar p Point = Other{}", "cannot use Other{X: 0, Y: 0} (STRUCT) as Point value in variable declaration"},
+		{pointType + "type Other struct { X int; Y int }\nPoint{} == Other{}", "type mismatch: Point == Other"},
+		{pointType + "Point{} + Point{}", "unknown operator: STRUCT + STRUCT"},
+		{"let n = 1; n{}", "n is not a type"},
+		{"Missing{}", "identifier not found: Missing"},
+		{"type Node struct { Next Node }", "invalid recursive type Node"},
+		{"type A struct { B }\ntype B struct { A }", "invalid recursive type B"},
+		{"type A struct { In struct { A A } }", "invalid recursive type A"},
+		{"type A struct { X int }\ntype B struct { X int }\ntype C struct { A; B }\nC{}.X", "ambiguous selector C.X"},
+		{"let x = 1; x.Y = 2", "INTEGER has no field Y"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(t, tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestInvalidRecursiveTypeIsNotBound(t *testing.T) {
+	tests := []struct {
+		declaration string
+		name        string
+	}{
+		{"type N struct { next N }", "N"},
+	}
+
+	for _, tt := range tests {
+		env := NewEnvironment()
+		evaluated := evalInput(t, tt.declaration, env)
+		if !isError(evaluated) {
+			t.Errorf("%q: expected an error, got %T (%+v)", tt.declaration, evaluated, evaluated)
+		}
+
+		// Declaring a variable of the rejected type must not recurse forever.
+		evalInput(t, "var v "+tt.name, env)
+
+		evaluated = evalInput(t, tt.name+"{}", env)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%q: rejected type %s is still bound", tt.declaration, tt.name)
+			continue
+		}
+		expected := "identifier not found: " + tt.name
+		if errObj.Message != expected {
+			t.Errorf("%q: wrong error message. expected=%q, got=%q", tt.declaration, expected, errObj.Message)
+		}
+	}
+
+	// A type the rejected declaration would have replaced is kept.
+	env := NewEnvironment()
+	evalInput(t, "type N struct { X int }\ntype N struct { next N }", env)
+	testIntegerObject(t, evalInput(t, "var n N\nn.X = 7\nn.X", env), 7)
+}
@@ -0,0 +1,169 @@
+package interpreter
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/object"
+)
+
+// convertTo returns val as a value of the type typ, resolved in env, or an
+// error if typ is not a type or val cannot be used as one. context names
+// where the conversion happens in the error.
+func convertTo(typ ast.Expression, val object.Object, env *Environment, context string) (object.Object, *object.Error) {
+	if err := checkType(typ, env); err != nil {
+		return nil, err
+	}
+	converted, ok := convertToType(typ, val, env)
+	if !ok {
+		return nil, object.NewError("cannot use %s (%s) as %s value in %s",
+			val.Inspect(), val.Type(), typ, context)
+	}
+	return converted, nil
+}
+
+// checkType reports an error if typ, resolved in env, names something that
+// is not a type.
+func checkType(typ ast.Expression, env *Environment) *object.Error {
+	switch typ := typ.(type) {
+	case *ast.ArrayType:
+		return checkType(typ.Element, env)
+
+	case *ast.StructType:
+		for _, field := range typ.Fields {
+			if err := checkType(field.Type, env); err != nil {
+				return err
+			}
+		}
+
+	case *ast.Identifier:
+		if isPredeclaredType(typ.Value) || typ.Value == "any" {
+			return nil
+		}
+		val, ok := env.Get(typ.Value)
+		if !ok {
+			return object.NewError("unknown type %s", typ.Value)
+		}
+		switch val.(type) {
+		case *StructType, *Class:
+		default:
+			return object.NewError("%s is not a type", typ.Value)
+		}
+	}
+	return nil
+}
+
+// convertToType returns val as a value of the type typ, resolved in env,
+// reporting false if val cannot be used as one. Integers widen to float and
+// complex, and floats to complex; an array converts element by element. A
+// struct type only accepts structs of that type, and a class its instances,
+// those of its subclasses and null. any accepts every value and a name that
+// is not a type none.
+func convertToType(typ ast.Expression, val object.Object, env *Environment) (object.Object, bool) {
+	switch typ := typ.(type) {
+	case *ast.ArrayType:
+		array, ok := val.(*object.Array)
+		if !ok {
+			return val, false
+		}
+		// The array is only copied if an element has to change type.
+		var converted []object.Object
+		for i, element := range array.Elements {
+			element, ok := convertToType(typ.Element, element, env)
+			if !ok {
+				return val, false
+			}
+			if converted == nil && element != array.Elements[i] {
+				converted = append([]object.Object{}, array.Elements...)
+			}
+			if converted != nil {
+				converted[i] = element
+			}
+		}
+		if converted != nil {
+			return &object.Array{Elements: converted}, true
+		}
+		return array, true
+
+	case *ast.Identifier:
+		switch typ.Value {
+		case "int":
+			return val, val.Type() == object.INTEGER_OBJ
+		case "float":
+			if isNumber(val) && val.Type() != object.COMPLEX_OBJ {
+				return &object.Float{Value: toFloat(val)}, true
+			}
+		case "complex":
+			if isNumber(val) {
+				return &object.Complex{Value: toComplex(val)}, true
+			}
+		case "string":
+			return val, val.Type() == object.STRING_OBJ
+		case "rune":
+			return val, val.Type() == object.RUNE_OBJ
+		case "bool", "boolean":
+			return val, val.Type() == object.BOOLEAN_OBJ
+		default:
+			if typ.Value == "any" {
+				return val, true
+			}
+			if st, ok := resolveStructType(typ, env); ok {
+				s, ok := val.(*Struct)
+				return val, ok && sameType(s.Def, st)
+			}
+			if class, ok := resolveClass(typ, env); ok {
+				instance, ok := val.(*Instance)
+				return val, val == object.NULL || ok && instance.Class.extends(class)
+			}
+		}
+
+	case *ast.StructType:
+		st, _ := resolveStructType(typ, env)
+		s, ok := val.(*Struct)
+		return val, ok && sameType(s.Def, st)
+	}
+	return val, false
+}
+
+// zeroValue returns the value a variable of type typ, resolved in env,
+// starts with when it is declared without one: 0, 0.0, 0i, "", the NUL rune,
+// false, an empty array or a struct of zero values. Variables of other types
+// start as null.
+func zeroValue(typ ast.Expression, env *Environment) object.Object {
+	switch typ := typ.(type) {
+	case *ast.ArrayType:
+		return &object.Array{Elements: []object.Object{}}
+
+	case *ast.StructType:
+		st, _ := resolveStructType(typ, env)
+		return st.zero()
+
+	case *ast.Identifier:
+		switch typ.Value {
+		case "int":
+			return &object.Integer{Value: 0}
+		case "float":
+			return &object.Float{Value: 0}
+		case "complex":
+			return &object.Complex{Value: 0}
+		case "string":
+			return &object.String{Value: ""}
+		case "rune":
+			return &object.Rune{Value: 0}
+		case "bool", "boolean":
+			return object.FALSE
+		default:
+			if st, ok := resolveStructType(typ, env); ok {
+				return st.zero()
+			}
+		}
+	}
+	return object.NULL
+}
+
+// isPredeclaredType reports whether name is one of the built-in type names.
+func isPredeclaredType(name string) bool {
+	switch name {
+	case "int", "float", "complex", "string", "rune", "bool", "boolean":
+		return true
+	}
+	return false
+}
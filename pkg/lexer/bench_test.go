@@ -0,0 +1,97 @@
+package lexer_test
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"kisumu/pkg/lexer"
+)
+
+// benchmarkSource is a synthetic script in the style of our generated
+// programs, a few thousand lines long.
+var benchmarkSource = func() string {
+	const chunk = `// compute the next value
+let counter_value = 0x1F + 1_000 * 3.5e2
+fn update(total, delta) {
+	if total >= 10 && delta != 0 {
+		return total - delta
+	}
+	let message = "total: \t" + name + ` + "`raw text`" + `
+	foreach item in items { total += item[0] }
+	return total / 2
+}
+`
+	return strings.Repeat(chunk, 500)
+}()
+
+// countTokens returns the number of tokens in benchmarkSource, EOF excluded.
+func countTokens(b *testing.B) int {
+	tokens, err := lexer.Tokenize(benchmarkSource).Tokens()
+	if err != nil {
+		b.Fatalf("benchmark source does not lex cleanly: %v", err)
+	}
+	return len(tokens)
+}
+
+// reportThroughput reports tokens/s and allocs/token for n tokens per
+// iteration, given the allocation count taken before the timed loop.
+func reportThroughput(b *testing.B, n int, mallocsBefore uint64) {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	total := float64(n) * float64(b.N)
+	b.ReportMetric(total/b.Elapsed().Seconds(), "tokens/s")
+	b.ReportMetric(float64(stats.Mallocs-mallocsBefore)/total, "allocs/token")
+}
+
+func BenchmarkTokenize(b *testing.B) {
+	n := countTokens(b)
+	b.SetBytes(int64(len(benchmarkSource)))
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l := lexer.Tokenize(benchmarkSource)
+		for tok := l.GetNextToken(); tok.Type != lexer.EOF; tok = l.GetNextToken() {
+		}
+	}
+	b.StopTimer()
+	reportThroughput(b, n, stats.Mallocs)
+}
+
+func BenchmarkTokenizeReader(b *testing.B) {
+	n := countTokens(b)
+	b.SetBytes(int64(len(benchmarkSource)))
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range lexer.TokenizeReader("", strings.NewReader(benchmarkSource)).All() {
+		}
+	}
+	b.StopTimer()
+	reportThroughput(b, n, stats.Mallocs)
+}
+
+// BenchmarkTokenizeReaderLongToken lexes a raw string much longer than a
+// single read, which should cost time and memory linear in its length.
+func BenchmarkTokenizeReaderLongToken(b *testing.B) {
+	source := "let s = `" + strings.Repeat("raw text ", 100000) + "`\n"
+	b.SetBytes(int64(len(source)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for range lexer.TokenizeReader("", strings.NewReader(source)).All() {
+		}
+	}
+}
+
+func BenchmarkLookupIdentifier(b *testing.B) {
+	idents := []string{"let", "counter_value", "fn", "update", "total", "continue", "return", "items", "x"}
+	for i := 0; i < b.N; i++ {
+		for _, ident := range idents {
+			lexer.LookupIdentifier(ident)
+		}
+	}
+}
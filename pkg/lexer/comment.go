@@ -0,0 +1,75 @@
+package lexer
+
+// Comment is a // line comment or /* */ block comment. Text includes the
+// comment markers, and for line comments excludes the trailing newline.
+type Comment struct {
+	Text  string
+	Start Position
+	End   Position
+}
+
+// IsBlock reports whether the comment is a /* */ comment.
+func (c Comment) IsBlock() bool {
+	return len(c.Text) > 1 && c.Text[1] == '*'
+}
+
+// RetainComments controls whether comments are kept. By default they are
+// skipped like whitespace; when retained, the comments in front of a token
+// are attached to its Comments field so tools such as a formatter or a doc
+// generator can reproduce them. Comments at the end of the input are
+// attached to the EOF token.
+func (l *Lexer) RetainComments(retain bool) {
+	l.retainComments = retain
+}
+
+// readLineComment skips a // comment up to, but not including, the end of the line.
+func (l *Lexer) readLineComment() {
+	start := l.currentPosition()
+	for l.currentChar != '\n' && l.currentChar != eof {
+		l.getChar()
+	}
+	l.addComment(start)
+}
+
+// readBlockComment skips a /* */ comment and reports whether it spanned a
+// newline. Block comments do not nest: a /* inside one is reported, as is a
+// comment that is never closed.
+func (l *Lexer) readBlockComment() (newline bool) {
+	start := l.currentPosition()
+	l.getChar() // skip the '/'
+	l.getChar() // skip the '*'
+
+	for {
+		switch {
+		case l.currentChar == eof:
+			l.addError(start, UnterminatedComment, "unterminated block comment")
+			l.addComment(start)
+			return newline
+		case l.currentChar == '*' && l.peekChar() == '/':
+			l.getChar()
+			l.getChar()
+			l.addComment(start)
+			return newline
+		case l.currentChar == '/' && l.peekChar() == '*':
+			l.addError(l.currentPosition(), NestedComment, "nested block comments are not supported; the comment started at %s ends at the first */", start)
+			l.getChar()
+			l.getChar()
+		default:
+			if l.currentChar == '\n' {
+				newline = true
+			}
+			l.getChar()
+		}
+	}
+}
+
+func (l *Lexer) addComment(start Position) {
+	if !l.retainComments {
+		return
+	}
+	l.comments = append(l.comments, Comment{
+		Text:  l.input[start.Offset-l.base : l.position],
+		Start: start,
+		End:   l.currentPosition(),
+	})
+}
@@ -0,0 +1,96 @@
+package lexer_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/lexer"
+)
+
+func TestCommentsAreSkipped(t *testing.T) {
+	input := `// leading comment
+let x = 5; // trailing comment
+/* block
+   comment */ let y = x / 2;
+/**/`
+
+	expected := []lexer.TokenType{
+		lexer.LET, lexer.IDENTIFIER, lexer.ASSIGNMENT, lexer.INT, lexer.SEMI_COLON,
+		lexer.LET, lexer.IDENTIFIER, lexer.ASSIGNMENT, lexer.IDENTIFIER, lexer.SLASH, lexer.INT, lexer.SEMI_COLON,
+		lexer.EOF,
+	}
+
+	lex := lexer.Tokenize(input)
+	for i, expectedType := range expected {
+		tok := lex.GetNextToken()
+		if tok.Type != expectedType {
+			t.Fatalf("tests[%d] - token type wrong. expected=%s, got=%s (%q)", i, expectedType, tok.Type, tok.Literal)
+		}
+		if tok.Comments != nil {
+			t.Errorf("tests[%d] - comments should not be retained by default, got %v", i, tok.Comments)
+		}
+	}
+	if len(lex.Errors()) != 0 {
+		t.Errorf("unexpected errors: %v", lex.Errors())
+	}
+}
+
+func TestCommentsRetainedAsTrivia(t *testing.T) {
+	input := "// add two numbers\n/* really */ let x = 1; // done\n"
+
+	lex := lexer.Tokenize(input)
+	lex.RetainComments(true)
+
+	tok := lex.GetNextToken()
+	if tok.Type != lexer.LET {
+		t.Fatalf("expected LET, got %s", tok.Type)
+	}
+	if len(tok.Comments) != 2 {
+		t.Fatalf("expected 2 comments on LET, got %d", len(tok.Comments))
+	}
+	if tok.Comments[0].Text != "// add two numbers" || tok.Comments[0].IsBlock() {
+		t.Errorf("first comment wrong. got=%+v", tok.Comments[0])
+	}
+	if tok.Comments[1].Text != "/* really */" || !tok.Comments[1].IsBlock() {
+		t.Errorf("second comment wrong. got=%+v", tok.Comments[1])
+	}
+	if pos := tok.Comments[1].Start; pos.Line != 2 || pos.Column != 1 {
+		t.Errorf("second comment position wrong. got=%s", pos)
+	}
+	if pos := tok.Comments[1].End; pos.Line != 2 || pos.Column != 13 {
+		t.Errorf("second comment end wrong. got=%s", pos)
+	}
+
+	for tok.Type != lexer.EOF {
+		tok = lex.GetNextToken()
+	}
+	if len(tok.Comments) != 1 || tok.Comments[0].Text != "// done" {
+		t.Errorf("expected trailing comment on EOF, got %+v", tok.Comments)
+	}
+}
+
+func TestCommentErrors(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedErrors []string
+	}{
+		{"let x = 1; /* never closed", []string{"1:12: unterminated block comment"}},
+		{"/* outer /* inner */ still outer? */", []string{
+			"1:10: nested block comments are not supported; the comment started at 1:1 ends at the first */",
+		}},
+	}
+
+	for i, tt := range tests {
+		lex := lexer.Tokenize(tt.input)
+		for tok := lex.GetNextToken(); tok.Type != lexer.EOF; tok = lex.GetNextToken() {
+		}
+		errors := lex.Errors()
+		if len(errors) != len(tt.expectedErrors) {
+			t.Fatalf("tests[%d] - expected %d errors, got %d: %v", i, len(tt.expectedErrors), len(errors), errors)
+		}
+		for j, expected := range tt.expectedErrors {
+			if errors[j].Error() != expected {
+				t.Errorf("tests[%d] - error %d wrong. expected=%q, got=%q", i, j, expected, errors[j])
+			}
+		}
+	}
+}
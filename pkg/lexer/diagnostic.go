@@ -0,0 +1,36 @@
+package lexer
+
+// DiagnosticCode classifies a Diagnostic, so that tools can react to a kind
+// of problem without matching on its message.
+type DiagnosticCode string
+
+const (
+	InvalidCharacter    DiagnosticCode = "invalid-character"    // a character that cannot start a token
+	InvalidEncoding     DiagnosticCode = "invalid-encoding"     // NUL, malformed UTF-8 or a misplaced byte order mark
+	InvalidNumber       DiagnosticCode = "invalid-number"       // a bad digit, or missing digits or exponent
+	InvalidEscape       DiagnosticCode = "invalid-escape"       // an unknown or malformed escape sequence
+	InvalidRune         DiagnosticCode = "invalid-rune"         // an empty or multi-character rune literal
+	UnterminatedLiteral DiagnosticCode = "unterminated-literal" // a string, raw string or rune literal
+	UnterminatedComment DiagnosticCode = "unterminated-comment" // a block comment without */
+	NestedComment       DiagnosticCode = "nested-comment"       // /* inside a block comment
+	ReadFailure         DiagnosticCode = "read-failure"         // the reader of a streaming lexer failed
+)
+
+// Diagnostic describes a problem found in the input. The lexer keeps going
+// after reporting one, so a single pass reports every problem.
+type Diagnostic struct {
+	Pos        Position
+	Code       DiagnosticCode
+	Message    string
+	Suggestion string // a possible fix, such as "did you mean '||'?", or ""
+}
+
+// Error formats the diagnostic as "pos: message", followed by the suggestion
+// in parentheses if there is one.
+func (d Diagnostic) Error() string {
+	msg := d.Pos.String() + ": " + d.Message
+	if d.Suggestion != "" {
+		msg += " (" + d.Suggestion + ")"
+	}
+	return msg
+}
@@ -0,0 +1,99 @@
+package lexer_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/lexer"
+)
+
+func TestDiagnostics(t *testing.T) {
+	tests := []struct {
+		input      string
+		expected   lexer.Diagnostic
+		formatted  string
+		tokenTypes []lexer.TokenType
+	}{
+		{
+			"a ~ b",
+			lexer.Diagnostic{Code: lexer.InvalidCharacter, Message: "unexpected character '~'"},
+			"1:3: unexpected character '~'",
+			[]lexer.TokenType{lexer.IDENTIFIER, lexer.IDENTIFIER},
+		},
+		{
+			"a @ b",
+			lexer.Diagnostic{Code: lexer.InvalidCharacter, Message: "unexpected character '@'"},
+			"1:3: unexpected character '@'",
+			[]lexer.TokenType{lexer.IDENTIFIER, lexer.IDENTIFIER},
+		},
+		{
+			"x = “hi",
+			lexer.Diagnostic{Code: lexer.InvalidCharacter, Message: "unexpected character '“'", Suggestion: `did you mean '"'?`},
+			`1:5: unexpected character '“' (did you mean '"'?)`,
+			[]lexer.TokenType{lexer.IDENTIFIER, lexer.ASSIGNMENT, lexer.IDENTIFIER},
+		},
+		{
+			`"open`,
+			lexer.Diagnostic{Code: lexer.UnterminatedLiteral, Message: "unterminated string literal"},
+			"1:1: unterminated string literal",
+			[]lexer.TokenType{lexer.STRING},
+		},
+		{
+			"0x",
+			lexer.Diagnostic{Code: lexer.InvalidNumber, Message: "hexadecimal literal has no digits"},
+			"1:3: hexadecimal literal has no digits",
+			[]lexer.TokenType{lexer.INT},
+		},
+	}
+
+	for i, tt := range tests {
+		lex := lexer.Tokenize(tt.input)
+		var types []lexer.TokenType
+		for tok := lex.GetNextToken(); tok.Type != lexer.EOF; tok = lex.GetNextToken() {
+			if tok.Type != lexer.SEMI_COLON {
+				types = append(types, tok.Type)
+			}
+		}
+
+		if len(types) != len(tt.tokenTypes) {
+			t.Errorf("tests[%d] - expected tokens %v, got %v", i, tt.tokenTypes, types)
+		} else {
+			for j := range types {
+				if types[j] != tt.tokenTypes[j] {
+					t.Errorf("tests[%d] - expected tokens %v, got %v", i, tt.tokenTypes, types)
+					break
+				}
+			}
+		}
+
+		errors := lex.Errors()
+		if len(errors) != 1 {
+			t.Fatalf("tests[%d] - expected 1 diagnostic, got %v", i, errors)
+		}
+		d := errors[0]
+		if d.Code != tt.expected.Code || d.Message != tt.expected.Message || d.Suggestion != tt.expected.Suggestion {
+			t.Errorf("tests[%d] - expected %+v, got %+v", i, tt.expected, d)
+		}
+		if d.Error() != tt.formatted {
+			t.Errorf("tests[%d] - expected %q, got %q", i, tt.formatted, d.Error())
+		}
+	}
+}
+
+func TestLexingContinuesAfterErrors(t *testing.T) {
+	lex := lexer.Tokenize("let $a = 1 @ 2\nlet b = 'xy'\n`")
+	for tok := lex.GetNextToken(); tok.Type != lexer.EOF; tok = lex.GetNextToken() {
+	}
+
+	expected := []lexer.DiagnosticCode{
+		lexer.InvalidCharacter, lexer.InvalidCharacter, lexer.InvalidRune, lexer.UnterminatedLiteral,
+	}
+	errors := lex.Errors()
+	if len(errors) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %v", len(expected), errors)
+	}
+	for i, code := range expected {
+		if errors[i].Code != code {
+			t.Errorf("errors[%d] - expected code %s, got %s (%v)", i, code, errors[i].Code, errors[i])
+		}
+	}
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func TestGetNextToken(t *testing.T) {
-	input := "=+=-*/<>=!&&||...;:, \t\n\r"
+	input := "=+=-*/<>=!&&||... .. ..<;: :=, % & | ^ &^ << >> <= \t\n\r"
 	lex := lexer.Tokenize(input)
 
 	tests := []struct {
@@ -25,10 +25,21 @@ func TestGetNextToken(t *testing.T) {
 		{lexer.BANG, "!"},
 		{lexer.AND, "&&"},
 		{lexer.OR, "||"},
-		{lexer.DOT_DOT, "..."},
+		{lexer.ELLIPSIS, "..."},
+		{lexer.DOT_DOT, ".."},
+		{lexer.DOT_DOT_LESS, "..<"},
 		{lexer.SEMI_COLON, ";"},
 		{lexer.COLON, ":"},
+		{lexer.COLON_EQUALS, ":="},
 		{lexer.COMMA, ","},
+		{lexer.PERCENT, "%"},
+		{lexer.AMPERSAND, "&"},
+		{lexer.PIPE, "|"},
+		{lexer.CARET, "^"},
+		{lexer.AND_NOT, "&^"},
+		{lexer.SHIFT_LEFT, "<<"},
+		{lexer.SHIFT_RIGHT, ">>"},
+		{lexer.LESS_EQUAL, "<="},
 	}
 
 	for i, test := range tests {
@@ -0,0 +1,102 @@
+package lexer_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/lexer"
+)
+
+func TestNumberLiterals(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedType    lexer.TokenType
+		expectedLiteral string
+	}{
+		{"42", lexer.INT, "42"},
+		{"1_000_000", lexer.INT, "1_000_000"},
+		{"0x1F", lexer.INT, "0x1F"},
+		{"0XfF", lexer.INT, "0XfF"},
+		{"0o17", lexer.INT, "0o17"},
+		{"0b1010", lexer.INT, "0b1010"},
+		{"017", lexer.INT, "017"},
+		{"3.14", lexer.FLOAT, "3.14"},
+		{"1.", lexer.FLOAT, "1."},
+		{".5", lexer.FLOAT, ".5"},
+		{"1e9", lexer.FLOAT, "1e9"},
+		{"6.02E+23", lexer.FLOAT, "6.02E+23"},
+		{"1.5e-3", lexer.FLOAT, "1.5e-3"},
+		{"2.5i", lexer.IMAGINARY, "2.5i"},
+		{"3i", lexer.IMAGINARY, "3i"},
+		{"1e2i", lexer.IMAGINARY, "1e2i"},
+	}
+
+	for i, tt := range tests {
+		lex := lexer.Tokenize(tt.input)
+		tok := lex.GetNextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Errorf("tests[%d] - wrong token. expected=%q (%s), got=%q (%s)",
+				i, tt.expectedLiteral, tt.expectedType, tok.Literal, tok.Type)
+		}
+		if next := lex.GetNextToken(); next.Type != lexer.SEMI_COLON || next.Literal != "\n" {
+			t.Errorf("tests[%d] - expected automatic semicolon after %q, got %s (%q)", i, tt.input, next.Type, next.Literal)
+		}
+		if len(lex.Errors()) != 0 {
+			t.Errorf("tests[%d] - unexpected errors: %v", i, lex.Errors())
+		}
+	}
+}
+
+func TestNumberFollowedByRangeOrExpression(t *testing.T) {
+	input := "10 * -2 + 2.4 - x + 1..5 + 0..<n"
+	expected := []struct {
+		expectedType    lexer.TokenType
+		expectedLiteral string
+	}{
+		{lexer.INT, "10"},
+		{lexer.ASTERISK, "*"},
+		{lexer.DASH, "-"},
+		{lexer.INT, "2"},
+		{lexer.PLUS, "+"},
+		{lexer.FLOAT, "2.4"},
+		{lexer.DASH, "-"},
+		{lexer.IDENTIFIER, "x"},
+		{lexer.PLUS, "+"},
+		{lexer.INT, "1"},
+		{lexer.DOT_DOT, ".."},
+		{lexer.INT, "5"},
+		{lexer.PLUS, "+"},
+		{lexer.INT, "0"},
+		{lexer.DOT_DOT_LESS, "..<"},
+		{lexer.IDENTIFIER, "n"},
+	}
+
+	lex := lexer.Tokenize(input)
+	for i, tt := range expected {
+		tok := lex.GetNextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong token. expected=%q (%s), got=%q (%s)",
+				i, tt.expectedLiteral, tt.expectedType, tok.Literal, tok.Type)
+		}
+	}
+}
+
+func TestNumberLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"0b102", "1:5: invalid digit '2' in binary literal"},
+		{"0o78", "1:4: invalid digit '8' in octal literal"},
+		{"0x", "1:3: hexadecimal literal has no digits"},
+		{"1e+", "1:4: exponent has no digits"},
+	}
+
+	for i, tt := range tests {
+		lex := lexer.Tokenize(tt.input)
+		lex.GetNextToken()
+		errors := lex.Errors()
+		if len(errors) != 1 || errors[0].Error() != tt.expectedError {
+			t.Errorf("tests[%d] - expected error %q, got %v", i, tt.expectedError, errors)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package lexer_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/lexer"
+)
+
+func TestTokenPositions(t *testing.T) {
+	input := "let five = 5;\n  five == 10;"
+
+	tests := []struct {
+		expectedType  lexer.TokenType
+		expectedStart lexer.Position
+		expectedEnd   lexer.Position
+	}{
+		{lexer.LET, lexer.Position{Filename: "pos.ksm", Offset: 0, Line: 1, Column: 1}, lexer.Position{Filename: "pos.ksm", Offset: 3, Line: 1, Column: 4}},
+		{lexer.IDENTIFIER, lexer.Position{Filename: "pos.ksm", Offset: 4, Line: 1, Column: 5}, lexer.Position{Filename: "pos.ksm", Offset: 8, Line: 1, Column: 9}},
+		{lexer.ASSIGNMENT, lexer.Position{Filename: "pos.ksm", Offset: 9, Line: 1, Column: 10}, lexer.Position{Filename: "pos.ksm", Offset: 10, Line: 1, Column: 11}},
+		{lexer.INT, lexer.Position{Filename: "pos.ksm", Offset: 11, Line: 1, Column: 12}, lexer.Position{Filename: "pos.ksm", Offset: 12, Line: 1, Column: 13}},
+		{lexer.SEMI_COLON, lexer.Position{Filename: "pos.ksm", Offset: 12, Line: 1, Column: 13}, lexer.Position{Filename: "pos.ksm", Offset: 13, Line: 1, Column: 14}},
+		{lexer.IDENTIFIER, lexer.Position{Filename: "pos.ksm", Offset: 16, Line: 2, Column: 3}, lexer.Position{Filename: "pos.ksm", Offset: 20, Line: 2, Column: 7}},
+		{lexer.EQUALS, lexer.Position{Filename: "pos.ksm", Offset: 21, Line: 2, Column: 8}, lexer.Position{Filename: "pos.ksm", Offset: 23, Line: 2, Column: 10}},
+		{lexer.INT, lexer.Position{Filename: "pos.ksm", Offset: 24, Line: 2, Column: 11}, lexer.Position{Filename: "pos.ksm", Offset: 26, Line: 2, Column: 13}},
+		{lexer.SEMI_COLON, lexer.Position{Filename: "pos.ksm", Offset: 26, Line: 2, Column: 13}, lexer.Position{Filename: "pos.ksm", Offset: 27, Line: 2, Column: 14}},
+		{lexer.EOF, lexer.Position{Filename: "pos.ksm", Offset: 27, Line: 2, Column: 14}, lexer.Position{Filename: "pos.ksm", Offset: 27, Line: 2, Column: 14}},
+	}
+
+	lex := lexer.TokenizeFile("pos.ksm", input)
+	for i, tt := range tests {
+		tok := lex.GetNextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("test[%d] - token type wrong. expected=%s, got=%s", i, tt.expectedType, tok.Type)
+		}
+		if tok.Start != tt.expectedStart {
+			t.Errorf("test[%d] - start wrong. expected=%+v, got=%+v", i, tt.expectedStart, tok.Start)
+		}
+		if tok.End != tt.expectedEnd {
+			t.Errorf("test[%d] - end wrong. expected=%+v, got=%+v", i, tt.expectedEnd, tok.End)
+		}
+	}
+}
+
+func TestPositionString(t *testing.T) {
+	tests := []struct {
+		pos      lexer.Position
+		expected string
+	}{
+		{lexer.Position{Filename: "file.ksm", Line: 3, Column: 14}, "file.ksm:3:14"},
+		{lexer.Position{Line: 3, Column: 14}, "3:14"},
+		{lexer.Position{Filename: "file.ksm"}, "file.ksm"},
+		{lexer.Position{}, "-"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.pos.String(); got != tt.expected {
+			t.Errorf("Position.String() wrong. expected=%q, got=%q", tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package lexer
+
+import (
+	"errors"
+	"io"
+	"iter"
+)
+
+// readChunkSize is how many bytes a streaming lexer asks its reader for at a
+// time.
+const readChunkSize = 4096
+
+// maxEmptyReads is how many reads in a row may return no data and no error
+// before the reader is considered broken.
+const maxEmptyReads = 100
+
+// TokenizeReader is like TokenizeFile but reads the source from r as tokens
+// are requested, so that large files and piped input can be lexed without
+// loading them into memory first. Only the token being scanned, and the
+// comments before it, are kept in memory.
+//
+// If reading fails the lexer stops as if the input had ended there and
+// records the failure in Errors; Tokens returns it as its error.
+func TokenizeReader(filename string, r io.Reader) *Lexer {
+	return (&Lexer{filename: filename, reader: r}).start()
+}
+
+// fill reads from the underlying reader until input holds at least n bytes or
+// the reader is exhausted. Callers check l.reader first, so that lexers made
+// from a string do not pay for the call.
+func (l *Lexer) fill(n int) {
+	for empty := 0; l.reader != nil && len(l.input) < n; {
+		if l.chunk == nil {
+			l.chunk = make([]byte, readChunkSize)
+		}
+		k, err := l.reader.Read(l.chunk)
+		if k > 0 {
+			l.appendInput(l.chunk[:k])
+			empty = 0
+		} else if err == nil {
+			if empty++; empty >= maxEmptyReads {
+				err = io.ErrNoProgress
+			}
+		}
+		if err != nil {
+			if err != io.EOF {
+				l.readErr = err
+				l.addError(l.inputEnd(), ReadFailure, "error reading input: %v", err)
+			}
+			l.reader = nil
+		}
+	}
+}
+
+// appendInput adds data to the end of input. input is a view of window,
+// which grows by doubling, so that a long token is not copied again on every
+// read; the discarded bytes at the start of window are only dropped once they
+// make up at least half of it.
+func (l *Lexer) appendInput(data []byte) {
+	if l.dead > 0 && l.dead >= len(l.input) {
+		live := l.input
+		l.window.Reset()
+		l.window.Grow(len(live) + len(data))
+		l.window.WriteString(live)
+		l.dead = 0
+	}
+	l.window.Write(data)
+	l.input = l.window.String()[l.dead:]
+}
+
+// inputEnd returns the position just after the last byte read so far.
+func (l *Lexer) inputEnd() Position {
+	pos := l.currentPosition()
+	for i := l.position; i < len(l.input); i++ {
+		if l.input[i] == '\n' {
+			pos.Line++
+			pos.Column = 1
+		} else {
+			pos.Column++
+		}
+	}
+	pos.Offset = l.base + len(l.input)
+	return pos
+}
+
+// discard drops the part of the input before currentChar, which no token
+// still to be scanned can refer to.
+func (l *Lexer) discard() {
+	if l.reader == nil || l.position == 0 {
+		return
+	}
+	l.input = l.input[l.position:]
+	l.dead += l.position
+	l.base += l.position
+	l.readPosition -= l.position
+	l.position = 0
+}
+
+// All returns an iterator over the remaining tokens of the input, stopping
+// before the EOF token:
+//
+//	for tok := range lexer.TokenizeReader("main.ksm", f).All() {
+//		...
+//	}
+func (l *Lexer) All() iter.Seq[Token] {
+	return func(yield func(Token) bool) {
+		for {
+			tok := l.GetNextToken()
+			if tok.Type == EOF || !yield(tok) {
+				return
+			}
+		}
+	}
+}
+
+// Tokens returns the remaining tokens of the input, without the final EOF
+// token. The error is the read error if the input could not be read, or
+// else one listing every problem reported in Errors, or nil if there were
+// none.
+func (l *Lexer) Tokens() ([]Token, error) {
+	var tokens []Token
+	for tok := range l.All() {
+		tokens = append(tokens, tok)
+	}
+
+	if l.readErr != nil {
+		return tokens, l.readErr
+	}
+	if len(l.errors) != 0 {
+		errs := make([]error, len(l.errors))
+		for i, d := range l.errors {
+			errs[i] = d
+		}
+		return tokens, errors.Join(errs...)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,150 @@
+package lexer_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"kisumu/pkg/lexer"
+)
+
+const streamingSource = `// greeting
+let name = "Ĝoŝ\U0001F600\t"
+let π = 3.14_15e0 /* block
+comment */ let r = '日'
+let raw = ` + "`a\\b`" + `
+return name + raw
+`
+
+func TestTokenizeReaderMatchesTokenize(t *testing.T) {
+	readers := map[string]func(io.Reader) io.Reader{
+		"whole":    func(r io.Reader) io.Reader { return r },
+		"one byte": iotest.OneByteReader,
+		"half":     iotest.HalfReader,
+		"data err": iotest.DataErrReader,
+	}
+
+	want := lexer.TokenizeFile("a.ksm", streamingSource)
+	want.RetainComments(true)
+	expected, err := want.Tokens()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, wrap := range readers {
+		l := lexer.TokenizeReader("a.ksm", wrap(strings.NewReader(streamingSource)))
+		l.RetainComments(true)
+		got, err := l.Tokens()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if len(got) != len(expected) {
+			t.Errorf("%s: expected %d tokens, got %d", name, len(expected), len(got))
+			continue
+		}
+		for i := range expected {
+			e, g := expected[i], got[i]
+			if e.Type != g.Type || e.Literal != g.Literal || e.Start != g.Start || e.End != g.End {
+				t.Errorf("%s: tokens[%d] - expected %s %q at %s, got %s %q at %s",
+					name, i, e.Type, e.Literal, e.Start, g.Type, g.Literal, g.Start)
+			}
+			if len(e.Comments) != len(g.Comments) {
+				t.Errorf("%s: tokens[%d] - expected %d comments, got %d", name, i, len(e.Comments), len(g.Comments))
+				continue
+			}
+			for j := range e.Comments {
+				if e.Comments[j] != g.Comments[j] {
+					t.Errorf("%s: tokens[%d] - expected comment %+v, got %+v", name, i, e.Comments[j], g.Comments[j])
+				}
+			}
+		}
+	}
+}
+
+func TestTokenizeReaderLargeInput(t *testing.T) {
+	var sb strings.Builder
+	const lines = 2000
+	for i := 0; i < lines; i++ {
+		sb.WriteString("let x = x + 1\n")
+	}
+
+	count := 0
+	var last lexer.Token
+	for tok := range lexer.TokenizeReader("", strings.NewReader(sb.String())).All() {
+		count++
+		last = tok
+	}
+	if count != lines*7 {
+		t.Errorf("expected %d tokens, got %d", lines*7, count)
+	}
+	if last.Start.Line != lines || last.Start.Offset != sb.Len()-1 {
+		t.Errorf("last token at unexpected position %s (offset %d)", last.Start, last.Start.Offset)
+	}
+}
+
+func TestTokenizeReaderLongToken(t *testing.T) {
+	// Tokens spanning many reads, after a discarded prefix.
+	raw := "`" + strings.Repeat("kisumu ", 10000) + "`"
+	comment := "// " + strings.Repeat("x", 20000) + "\n"
+	input := "let a = 1\nlet s = " + raw + "\n" + comment + "let b = 2\n"
+
+	expected, err := lexer.Tokenize(input).Tokens()
+	if err != nil {
+		t.Fatalf("Tokenize: %v", err)
+	}
+	got, err := lexer.TokenizeReader("", iotest.HalfReader(strings.NewReader(input))).Tokens()
+	if err != nil {
+		t.Fatalf("TokenizeReader: %v", err)
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(got))
+	}
+	for i := range expected {
+		if got[i].Type != expected[i].Type || got[i].Literal != expected[i].Literal || got[i].Start != expected[i].Start {
+			t.Errorf("tokens[%d] - expected %s %.20q at %s, got %s %.20q at %s", i,
+				expected[i].Type, expected[i].Literal, expected[i].Start, got[i].Type, got[i].Literal, got[i].Start)
+		}
+	}
+}
+
+func TestTokenizeReaderError(t *testing.T) {
+	failure := errors.New("disk on fire")
+	r := io.MultiReader(strings.NewReader("let x = 1\n"), iotest.ErrReader(failure))
+
+	l := lexer.TokenizeReader("a.ksm", r)
+	tokens, err := l.Tokens()
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected read error, got %v", err)
+	}
+	if len(tokens) != 5 {
+		t.Errorf("expected the 5 tokens read before the error, got %d", len(tokens))
+	}
+	if errs := l.Errors(); len(errs) != 1 || errs[0].Error() != "a.ksm:2:1: error reading input: disk on fire" {
+		t.Errorf("unexpected lexer errors %q", errs)
+	}
+}
+
+func TestAllStopsEarly(t *testing.T) {
+	l := lexer.Tokenize("a b c d")
+	for tok := range l.All() {
+		if tok.Literal == "b" {
+			break
+		}
+	}
+	if tok := l.GetNextToken(); tok.Literal != "c" {
+		t.Errorf("expected lexing to resume at c, got %q", tok.Literal)
+	}
+}
+
+func TestTokensReportsLexErrors(t *testing.T) {
+	tokens, err := lexer.Tokenize(`let s = "open`).Tokens()
+	if err == nil || !strings.Contains(err.Error(), "unterminated string literal") {
+		t.Errorf("expected unterminated string error, got %v", err)
+	}
+	if len(tokens) == 0 {
+		t.Error("expected tokens before the error")
+	}
+}
@@ -0,0 +1,80 @@
+package lexer_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/lexer"
+)
+
+func TestAutomaticSemicolonInsertion(t *testing.T) {
+	input := `let x = 5
+let s = "hi" // greeting
+return
+x++
+foo(x)
+arr[0] /* a
+comment */ y
+if x {
+}
+let z = x +
+	1
+var ok boolean
+return this
+super
+`
+
+	expected := []struct {
+		expectedType    lexer.TokenType
+		expectedLiteral string
+	}{
+		{lexer.LET, "let"}, {lexer.IDENTIFIER, "x"}, {lexer.ASSIGNMENT, "="}, {lexer.INT, "5"}, {lexer.SEMI_COLON, "\n"},
+		{lexer.LET, "let"}, {lexer.IDENTIFIER, "s"}, {lexer.ASSIGNMENT, "="}, {lexer.STRING, "hi"}, {lexer.SEMI_COLON, "\n"},
+		{lexer.RETURN, "return"}, {lexer.SEMI_COLON, "\n"},
+		{lexer.IDENTIFIER, "x"}, {lexer.PLUS_PLUS, "++"}, {lexer.SEMI_COLON, "\n"},
+		{lexer.IDENTIFIER, "foo"}, {lexer.OPEN_PARENTHESES, "("}, {lexer.IDENTIFIER, "x"}, {lexer.CLOSE_PARENTHESES, ")"}, {lexer.SEMI_COLON, "\n"},
+		{lexer.IDENTIFIER, "arr"}, {lexer.OPEN_BRACKET, "["}, {lexer.INT, "0"}, {lexer.CLOSE_BRACKET, "]"}, {lexer.SEMI_COLON, "\n"},
+		{lexer.IDENTIFIER, "y"}, {lexer.SEMI_COLON, "\n"},
+		{lexer.IF, "if"}, {lexer.IDENTIFIER, "x"}, {lexer.OPEN_CURLY, "{"},
+		{lexer.CLOSE_CURLY, "}"}, {lexer.SEMI_COLON, "\n"},
+		{lexer.LET, "let"}, {lexer.IDENTIFIER, "z"}, {lexer.ASSIGNMENT, "="}, {lexer.IDENTIFIER, "x"}, {lexer.PLUS, "+"},
+		{lexer.INT, "1"}, {lexer.SEMI_COLON, "\n"},
+		{lexer.VAR, "var"}, {lexer.IDENTIFIER, "ok"}, {lexer.IDENTIFIER, "boolean"}, {lexer.SEMI_COLON, "\n"},
+		{lexer.RETURN, "return"}, {lexer.THIS, "this"}, {lexer.SEMI_COLON, "\n"},
+		{lexer.SUPER, "super"}, {lexer.SEMI_COLON, "\n"},
+		{lexer.EOF, ""},
+	}
+
+	lex := lexer.Tokenize(input)
+	for i, tt := range expected {
+		tok := lex.GetNextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong token. expected=%q (%s), got=%q (%s) at %s",
+				i, tt.expectedLiteral, tt.expectedType, tok.Literal, tok.Type, tok.Start)
+		}
+	}
+}
+
+func TestNoSemicolonAfterExplicitSemicolon(t *testing.T) {
+	lex := lexer.Tokenize("x;\n\n\ny")
+
+	expected := []lexer.TokenType{lexer.IDENTIFIER, lexer.SEMI_COLON, lexer.IDENTIFIER, lexer.SEMI_COLON, lexer.EOF}
+	for i, expectedType := range expected {
+		tok := lex.GetNextToken()
+		if tok.Type != expectedType {
+			t.Fatalf("tests[%d] - expected %s, got %s (%q)", i, expectedType, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestAutomaticSemicolonPosition(t *testing.T) {
+	lex := lexer.Tokenize("abc\ndef")
+	lex.GetNextToken()
+	semi := lex.GetNextToken()
+	if semi.Start.Line != 1 || semi.Start.Column != 4 {
+		t.Errorf("semicolon should be at the newline, got %s", semi.Start)
+	}
+	next := lex.GetNextToken()
+	if next.Literal != "def" || next.Start.Line != 2 || next.Start.Column != 1 {
+		t.Errorf("expected def at 2:1, got %q at %s", next.Literal, next.Start)
+	}
+}
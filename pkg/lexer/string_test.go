@@ -0,0 +1,120 @@
+package lexer_test
+
+import (
+	"strings"
+	"testing"
+
+	"kisumu/pkg/lexer"
+)
+
+func TestStringLiterals(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedLiteral string
+	}{
+		{`"World"`, "World"},
+		{`""`, ""},
+		{`"a\nb\tc"`, "a\nb\tc"},
+		{`"say \"hi\""`, `say "hi"`},
+		{`"back\\slash"`, `back\slash`},
+		{`"\x41\x42"`, "AB"},
+		{`"café"`, "café"},
+		{`"\U0001F600"`, "\U0001F600"},
+		{`"habari yako"`, "habari yako"},
+		{"`raw\\n\nstring`", "raw\\n\nstring"},
+		{"`a\r\nb`", "a\nb"},
+	}
+
+	for i, tt := range tests {
+		lex := lexer.Tokenize(tt.input)
+		tok := lex.GetNextToken()
+		if tok.Type != lexer.STRING {
+			t.Fatalf("tests[%d] - token type wrong. expected=STRING, got=%s", i, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Errorf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+		if len(lex.Errors()) != 0 {
+			t.Errorf("tests[%d] - unexpected errors: %v", i, lex.Errors())
+		}
+		if next := lex.GetNextToken(); next.Type != lexer.SEMI_COLON || next.Literal != "\n" {
+			t.Errorf("tests[%d] - expected automatic semicolon after string, got %s", i, next.Type)
+		}
+	}
+}
+
+func TestStringLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{`let s = "abc`, "1:9: unterminated string literal"},
+		{"\"abc\nlet", "1:1: unterminated string literal"},
+		{"let s = `abc", "1:9: unterminated raw string literal"},
+		{`"bad \q escape"`, `1:6: invalid escape sequence \q`},
+	}
+
+	for i, tt := range tests {
+		lex := lexer.Tokenize(tt.input)
+		for tok := lex.GetNextToken(); tok.Type != lexer.EOF; tok = lex.GetNextToken() {
+		}
+		errors := lex.Errors()
+		if len(errors) != 1 {
+			t.Fatalf("tests[%d] - expected 1 error, got %d: %v", i, len(errors), errors)
+		}
+		if !strings.HasPrefix(errors[0].Error(), tt.expectedError) {
+			t.Errorf("tests[%d] - wrong error. expected=%q, got=%q", i, tt.expectedError, errors[0].Error())
+		}
+	}
+}
+
+func TestRuneLiterals(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedLiteral string
+	}{
+		{`'a'`, "a"},
+		{`'\n'`, "\n"},
+		{`'é'`, "é"},
+		{`'\''`, "'"},
+		{`'"'`, `"`},
+		{`'\x41'`, "A"},
+		{`'\u00e9'`, "é"},
+	}
+
+	for i, tt := range tests {
+		lex := lexer.Tokenize(tt.input)
+		tok := lex.GetNextToken()
+		if tok.Type != lexer.RUNE || tok.Literal != tt.expectedLiteral {
+			t.Errorf("tests[%d] - wrong token. expected=%q (RUNE), got=%q (%s)", i, tt.expectedLiteral, tok.Literal, tok.Type)
+		}
+		if len(lex.Errors()) != 0 {
+			t.Errorf("tests[%d] - unexpected errors: %v", i, lex.Errors())
+		}
+		if next := lex.GetNextToken(); next.Type != lexer.SEMI_COLON || next.Literal != "\n" {
+			t.Errorf("tests[%d] - expected automatic semicolon after rune, got %s", i, next.Type)
+		}
+	}
+}
+
+func TestRuneLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{`''`, "1:1: empty rune literal or unescaped ' in rune literal"},
+		{`'ab'`, "1:1: more than one character in rune literal"},
+		{`x = 'a`, "1:5: unterminated rune literal"},
+		{`'\"'`, `1:2: invalid escape sequence \"`},
+	}
+
+	for i, tt := range tests {
+		lex := lexer.Tokenize(tt.input)
+		for tok := lex.GetNextToken(); tok.Type != lexer.EOF; tok = lex.GetNextToken() {
+		}
+		errors := lex.Errors()
+		if len(errors) == 0 || errors[0].Error() != tt.expectedError {
+			t.Errorf("tests[%d] - expected error %q, got %v", i, tt.expectedError, errors)
+		}
+	}
+}
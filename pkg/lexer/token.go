@@ -2,142 +2,272 @@ package lexer
 
 import (
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-type TokenType string
+// TokenType identifies the kind of a token. The zero value is ILLEGAL.
+type TokenType uint8
 
 type Token struct {
-	Type    TokenType
-	Literal string
+	Type     TokenType
+	Literal  string
+	Start    Position  // position of the first character of the token
+	End      Position  // position immediately after the last character of the token
+	Comments []Comment // comments directly before the token, only kept when RetainComments is on
+}
+
+// Position describes a location in the source. Line and Column start at 1;
+// as in Go, Column counts bytes, so a multi-byte character advances it by
+// its UTF-8 length. Offset is the byte offset from the start of the input and
+// Filename is empty unless the lexer was created with TokenizeFile.
+type Position struct {
+	Filename string
+	Offset   int
+	Line     int
+	Column   int
+}
+
+// IsValid reports whether the position has line information.
+func (pos Position) IsValid() bool {
+	return pos.Line > 0
+}
+
+// String returns the position as file:line:col, or line:col when there is no filename.
+func (pos Position) String() string {
+	s := pos.Filename
+	if pos.IsValid() {
+		if s != "" {
+			s += ":"
+		}
+		s += fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+	}
+	if s == "" {
+		s = "-"
+	}
+	return s
 }
 
 type Lexer struct { // position and readPosition are used to access characters in input(as index)
-	input        string
-	position     int  // index of the starting position of the current token(the previous character)
-	readPosition int  // index of the current character
-	currentChar  byte // current character under examination
+	input        string // the source, or for a streaming lexer the part of it not yet discarded
+	filename     string
+	base         int  // offset in the source of input[0]
+	position     int  // byte index of currentChar
+	readPosition int  // byte index of the character after currentChar
+	currentChar  rune // current character under examination, or eof
+	line         int  // line of currentChar, starting at 1
+	column       int  // column of currentChar, starting at 1
+	errors       []Diagnostic
+
+	retainComments bool
+	comments       []Comment // comments read since the last token
+
+	insertSemi bool // whether a newline or EOF here ends the statement
+
+	reader  io.Reader       // source of further input for a lexer made by TokenizeReader
+	readErr error           // the error that stopped reading, other than io.EOF
+	chunk   []byte          // buffer for reads from reader
+	window  strings.Builder // for a streaming lexer, the bytes read that input is a view of
+	dead    int             // bytes at the start of window that have been discarded from input
 }
 
 const (
-	ILLEGAL = "ILLEGAL" // Invalid token/unknown character
-	EOF     = "EOF"     // End of file
+	ILLEGAL TokenType = iota // Invalid token/unknown character
+	EOF                      // End of file
 
 	// Identifiers and literals
-	KEYWORD     = "KEYWORD"     // break, continue, else, for, if, return, struct, var
-	RETURN_TYPE = "RETURN_TYPE" // int, string, etc.
-	STRUCT_TYPE = "STRUCT_TYPE" // struct { field1 type; field2 type; }
-	VAR         = "VAR"         // var     // const
-	TYPE        = "TYPE"        // int, string, etc.
-	BOOLEAN     = "BOOLEAN"     // true, false
-	FLOAT       = "FLOAT"       // 123.456
-	IMAGINARY   = "IMAGINARY"   // 123.456i
-	RUNE        = "RUNE"        // 'a'
-	INT         = "INT"         // 1323145567890
-	STRING      = "STRING"      // concatenate, slice, and get
-	IDENTIFIER  = "IDENTIFIER"  // variable name, function name, or struct name
+	KEYWORD     // break, continue, else, for, if, return, struct, var
+	RETURN_TYPE // int, string, etc.
+	STRUCT_TYPE // struct { field1 type; field2 type; }
+	VAR         // var     // const
+	TYPE        // int, string, etc.
+	BOOLEAN     // true, false
+	FLOAT       // 123.456
+	IMAGINARY   // 123.456i
+	RUNE        // 'a'
+	INT         // 1323145567890
+	STRING      // concatenate, slice, and get
+	IDENTIFIER  // variable name, function name, or struct name
 
 	// Operators and delimiters
-	OPEN_BRACKET      = "OPEN_BRACKET"      // [
-	CLOSE_BRACKET     = "CLOSE_BRACKET"     // ]
-	OPEN_CURLY        = "OPEN_CURLY"        // {
-	CLOSE_CURLY       = "CLOSE_CURLY"       // }
-	OPEN_PARENTHESES  = "OPEN_PARENTHESES"  // (
-	CLOSE_PARENTHESES = "CLOSE_PARENTHESES" // )
-
-	ASSIGNMENT = "ASSIGNMENT" // =
-	EQUALS     = "EQUALS"     // ==
-	NOT        = "NOT"
-	NOT_EQUALS = "NOT_EQUALS" // !=
-
-	LESS           = "LESS"           // <
-	LESS_EQUAL     = "LESS_EQUAL"     // <=
-	GREATER        = "GREATER"        // >
-	GREATER_EQUALS = "GREATER_EQUALS" // >=
-
-	OR    = "OR"    // ||
-	AND   = "AND"   // &&
-	NULL  = "NULL"  // null
-	TRUE  = "TRUE"  // true
-	FALSE = "FALSE" // false
-
-	BANG       = "BANG"
-	DOT        = "DOT"        //.
-	DOT_DOT    = "DOT_DOT"    //..
-	SEMI_COLON = "SEMI_COLON" // ;
-	COLON      = "COLON"      // :
-	QUESTION   = "QUESTION"   //?
-	COMMA      = "COMMA"      //,
-	WHITESPACE = "WHITESPACE" // Whitespace
-
-	PLUS_PLUS    = "PLUS_PLUS"    // ++
-	MINUS_MINUS  = "MINUS_MINUS"  // --
-	PLUS_EQUALS  = "PLUS_EQUALS"  // +=
-	MINUS_EQUALS = "MINUS_EQUALS" // -=
-	SLASH_EQUALS = "SLASH_EQUALS" // /=
-	STAR_EQUALS  = "STAR_EQUALS"  // *=
-
-	PLUS     = "PLUS"     // +
-	DASH     = "DASH"     // -
-	SLASH    = "SLASH"    // /
-	ASTERISK = "ASTERISK" // *
-	PERCENT  = "PERCENT"  // %
+	OPEN_BRACKET      // [
+	CLOSE_BRACKET     // ]
+	OPEN_CURLY        // {
+	CLOSE_CURLY       // }
+	OPEN_PARENTHESES  // (
+	CLOSE_PARENTHESES // )
+
+	ASSIGNMENT // =
+	EQUALS     // ==
+	NOT
+	NOT_EQUALS // !=
+
+	LESS           // <
+	LESS_EQUAL     // <=
+	GREATER        // >
+	GREATER_EQUALS // >=
+
+	OR    // ||
+	AND   // &&
+	NULL  // null
+	TRUE  // true
+	FALSE // false
+
+	BANG
+	DOT          // .
+	DOT_DOT      // ..
+	DOT_DOT_LESS // ..<
+	ELLIPSIS     // ...
+	SEMI_COLON   // ;
+	COLON        // :
+	COLON_EQUALS // :=
+	QUESTION     //?
+	COMMA        //,
+	WHITESPACE   // Whitespace
+
+	PLUS_PLUS    // ++
+	MINUS_MINUS  // --
+	PLUS_EQUALS  // +=
+	MINUS_EQUALS // -=
+	SLASH_EQUALS // /=
+	STAR_EQUALS  // *=
+
+	PLUS     // +
+	DASH     // -
+	SLASH    // /
+	ASTERISK // *
+	PERCENT  // %
+
+	AMPERSAND   // &
+	PIPE        // |
+	CARET       // ^
+	AND_NOT     // &^
+	SHIFT_LEFT  // <<
+	SHIFT_RIGHT // >>
 
 	/* ====== RESERVED KEYWORDS ======= */
-	LET      = "LET"      // let
-	CONST    = "CONST"    // const
-	CLASS    = "CLASS"    // class
-	NEW      = "NEW"      // new
-	IMPORT   = "IMPORT"   // import
-	FROM     = "FROM"     // from
-	FN       = "FUNCTION" // fn
-	IF       = "IF"       // if
-	ELSE     = "ELSE"     // else
-	FOREACH  = "FOREACH"  // foreach
-	WHILE    = "WHILE"    // while
-	FOR      = "FOR"      // for
-	EXPORT   = "EXPORT"   // export
-	TYPEOF   = "TYPEOF"   // typeof
-	IN       = "IN"       // in
-	RETURN   = "RETURN"   // return
-	BREAK    = "BREAK"    // break
-	CONTINUE = "CONTINUE" // continue
+	LET      // let
+	CONST    // const
+	CLASS    // class
+	NEW      // new
+	IMPORT   // import
+	FROM     // from
+	FN       // fn
+	IF       // if
+	ELSE     // else
+	FOREACH  // foreach
+	WHILE    // while
+	FOR      // for
+	EXPORT   // export
+	TYPEOF   // typeof
+	IN       // in
+	RETURN   // return
+	BREAK    // break
+	CONTINUE // continue
 
+	EXTENDS    // extends
+	THIS       // this
+	SUPER      // super
+	INSTANCEOF // instanceof
 )
 
-var KEYWORDS = map[string]TokenType{
-	"function":  FN,
-	"let":       LET,
-	"const":     CONST,
-	"class":     CLASS,
-	"new":       NEW,
-	"import":    IMPORT,
-	"from":      FROM,
-	"fn":        FN,
-	"if":        IF,
-	"else":      ELSE,
-	"foreach":   FOREACH,
-	"while":     WHILE,
-	"for":       FOR,
-	"export":    EXPORT,
-	"typeof":    TYPEOF,
-	"in":        IN,
-	"return":    RETURN,
-	"break":     BREAK,
-	"continue":  CONTINUE,
-	"null":      NULL,
-	"true":      TRUE,
-	"false":     FALSE,
-	"boolean":   BOOLEAN,
-	"float":     FLOAT,
-	"imaginary": IMAGINARY,
-	"rune":      RUNE,
-	"int":       INT,
-	"string":    STRING,
-	"struct":    STRUCT_TYPE,
-	"var":       VAR,
-	"type":      TYPE,
-	"or":        OR,
-	"and":       AND,
+// tokenNames holds the name of every token type, indexed by the type.
+var tokenNames = [...]string{
+	ILLEGAL:           "ILLEGAL",
+	EOF:               "EOF",
+	KEYWORD:           "KEYWORD",
+	RETURN_TYPE:       "RETURN_TYPE",
+	STRUCT_TYPE:       "STRUCT_TYPE",
+	VAR:               "VAR",
+	TYPE:              "TYPE",
+	BOOLEAN:           "BOOLEAN",
+	FLOAT:             "FLOAT",
+	IMAGINARY:         "IMAGINARY",
+	RUNE:              "RUNE",
+	INT:               "INT",
+	STRING:            "STRING",
+	IDENTIFIER:        "IDENTIFIER",
+	OPEN_BRACKET:      "OPEN_BRACKET",
+	CLOSE_BRACKET:     "CLOSE_BRACKET",
+	OPEN_CURLY:        "OPEN_CURLY",
+	CLOSE_CURLY:       "CLOSE_CURLY",
+	OPEN_PARENTHESES:  "OPEN_PARENTHESES",
+	CLOSE_PARENTHESES: "CLOSE_PARENTHESES",
+	ASSIGNMENT:        "ASSIGNMENT",
+	EQUALS:            "EQUALS",
+	NOT:               "NOT",
+	NOT_EQUALS:        "NOT_EQUALS",
+	LESS:              "LESS",
+	LESS_EQUAL:        "LESS_EQUAL",
+	GREATER:           "GREATER",
+	GREATER_EQUALS:    "GREATER_EQUALS",
+	OR:                "OR",
+	AND:               "AND",
+	NULL:              "NULL",
+	TRUE:              "TRUE",
+	FALSE:             "FALSE",
+	BANG:              "BANG",
+	DOT:               "DOT",
+	DOT_DOT:           "DOT_DOT",
+	DOT_DOT_LESS:      "DOT_DOT_LESS",
+	ELLIPSIS:          "ELLIPSIS",
+	SEMI_COLON:        "SEMI_COLON",
+	COLON:             "COLON",
+	COLON_EQUALS:      "COLON_EQUALS",
+	QUESTION:          "QUESTION",
+	COMMA:             "COMMA",
+	WHITESPACE:        "WHITESPACE",
+	PLUS_PLUS:         "PLUS_PLUS",
+	MINUS_MINUS:       "MINUS_MINUS",
+	PLUS_EQUALS:       "PLUS_EQUALS",
+	MINUS_EQUALS:      "MINUS_EQUALS",
+	SLASH_EQUALS:      "SLASH_EQUALS",
+	STAR_EQUALS:       "STAR_EQUALS",
+	PLUS:              "PLUS",
+	DASH:              "DASH",
+	SLASH:             "SLASH",
+	ASTERISK:          "ASTERISK",
+	PERCENT:           "PERCENT",
+	AMPERSAND:         "AMPERSAND",
+	PIPE:              "PIPE",
+	CARET:             "CARET",
+	AND_NOT:           "AND_NOT",
+	SHIFT_LEFT:        "SHIFT_LEFT",
+	SHIFT_RIGHT:       "SHIFT_RIGHT",
+	LET:               "LET",
+	CONST:             "CONST",
+	CLASS:             "CLASS",
+	NEW:               "NEW",
+	IMPORT:            "IMPORT",
+	FROM:              "FROM",
+	FN:                "FUNCTION",
+	IF:                "IF",
+	ELSE:              "ELSE",
+	FOREACH:           "FOREACH",
+	WHILE:             "WHILE",
+	FOR:               "FOR",
+	EXPORT:            "EXPORT",
+	TYPEOF:            "TYPEOF",
+	IN:                "IN",
+	RETURN:            "RETURN",
+	BREAK:             "BREAK",
+	CONTINUE:          "CONTINUE",
+	EXTENDS:           "EXTENDS",
+	THIS:              "THIS",
+	SUPER:             "SUPER",
+	INSTANCEOF:        "INSTANCEOF",
+}
+
+// String returns the name of the token type, such as "IDENTIFIER" or
+// "OPEN_PARENTHESES", as used in diagnostics.
+func (t TokenType) String() string {
+	if int(t) < len(tokenNames) {
+		return tokenNames[t]
+	}
+	return "TokenType(" + strconv.Itoa(int(t)) + ")"
 }
 
 func (token Token) isAmongDefined(expectedTokens ...TokenType) bool {
@@ -149,9 +279,98 @@ func (token Token) isAmongDefined(expectedTokens ...TokenType) bool {
 	return false
 }
 
-func LookupIdentifier(keyword string) TokenType {
-	if keywordToken, ok := KEYWORDS[keyword]; ok {
-		return keywordToken
+// LookupIdentifier returns the token type of ident: the keyword's type if it
+// is a reserved word, and IDENTIFIER otherwise. Type names such as int and
+// boolean are identifiers, not keywords. Keywords are matched by a
+// switch on the length first, so most identifiers are rejected after a
+// single comparison without hashing.
+func LookupIdentifier(ident string) TokenType {
+	switch len(ident) {
+	case 2:
+		switch ident {
+		case "fn":
+			return FN
+		case "if":
+			return IF
+		case "in":
+			return IN
+		case "or":
+			return OR
+		}
+	case 3:
+		switch ident {
+		case "let":
+			return LET
+		case "new":
+			return NEW
+		case "for":
+			return FOR
+		case "var":
+			return VAR
+		case "and":
+			return AND
+		}
+	case 4:
+		switch ident {
+		case "from":
+			return FROM
+		case "else":
+			return ELSE
+		case "null":
+			return NULL
+		case "true":
+			return TRUE
+		case "type":
+			return TYPE
+		case "this":
+			return THIS
+		}
+	case 5:
+		switch ident {
+		case "const":
+			return CONST
+		case "class":
+			return CLASS
+		case "while":
+			return WHILE
+		case "break":
+			return BREAK
+		case "false":
+			return FALSE
+		case "super":
+			return SUPER
+		}
+	case 6:
+		switch ident {
+		case "import":
+			return IMPORT
+		case "export":
+			return EXPORT
+		case "typeof":
+			return TYPEOF
+		case "return":
+			return RETURN
+		case "struct":
+			return STRUCT_TYPE
+		}
+	case 7:
+		switch ident {
+		case "foreach":
+			return FOREACH
+		case "extends":
+			return EXTENDS
+		}
+	case 8:
+		switch ident {
+		case "function":
+			return FN
+		case "continue":
+			return CONTINUE
+		}
+	case 10:
+		if ident == "instanceof" {
+			return INSTANCEOF
+		}
 	}
 	return IDENTIFIER
 }
@@ -163,15 +382,28 @@ func LookupIdentifier(keyword string) TokenType {
 */
 func (token Token) Debug() {
 	if token.isAmongDefined(IDENTIFIER, INT, STRING) {
-		fmt.Printf("%s (%s)\n", TokenType(token.Type), token.Literal)
+		fmt.Printf("%s (%s)\n", token.Type, token.Literal)
 	} else {
-		fmt.Printf("%s ()\n", TokenType(token.Type))
+		fmt.Printf("%s ()\n", token.Type)
 	}
 }
 
-func IsLetter(ch byte) bool {
+// eof is the value of currentChar once the whole input has been read.
+const eof = -1
+
+// byteOrderMark is ignored when it is the first character of the input.
+const byteOrderMark = 0xFEFF
+
+// IsLetter reports whether ch can start an identifier: a Unicode letter or '_'.
+func IsLetter(ch rune) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' ||
+		ch >= utf8.RuneSelf && unicode.IsLetter(ch)
+}
 
-	return 'a' <= ch && ch >= 'z' || 'A' <= ch && ch >= 'Z' || ch == '_'
+// isIdentifierChar reports whether ch can appear after the first character
+// of an identifier: a letter, '_' or a Unicode decimal digit.
+func isIdentifierChar(ch rune) bool {
+	return IsLetter(ch) || IsDigit(ch) || ch >= utf8.RuneSelf && unicode.IsDigit(ch)
 }
 
 func IsFloat(s string) bool {
@@ -179,7 +411,7 @@ func IsFloat(s string) bool {
 		if char == '.' {
 			return true
 		}
-		if !IsDigit(byte(char)) {
+		if !IsDigit(char) {
 			return false
 		}
 	}
@@ -196,7 +428,7 @@ func IsFloat(s string) bool {
 // - A string representing the extracted identifier.
 func (l *Lexer) readIdentifier() string {
 	startPosition := l.position
-	for IsLetter(byte(l.currentChar)) || l.currentChar == '_' {
+	for isIdentifierChar(l.currentChar) {
 		l.getChar()
 	}
 	return l.input[startPosition:l.position]
@@ -205,7 +437,8 @@ func (l *Lexer) readIdentifier() string {
 // Function used to create a lexer
 func NewToken(Type TokenType, value string) Token {
 	return Token{
-		Type, value,
+		Type:    Type,
+		Literal: value,
 	}
 }
 
@@ -218,87 +451,441 @@ func NewToken(Type TokenType, value string) Token {
 // Returns:
 // - A pointer to a new Lexer instance, initialized with the provided input string.
 func Tokenize(input string) *Lexer {
-	tok := &Lexer{input: input} // Create a new Lexer instance with the given input string
-	tok.getChar()               // Initialize the current character and position of the lexer
-	return tok                  // Return the initialized Lexer instance
+	return TokenizeFile("", input)
 }
 
-// getNextChar advances the lexer to the next character in the input string.
-// It updates the current character (currentChar), the current position (position),
-// and the read position (readPosition). If the read position is at or beyond the end of the input string,
-// the current character is set to 0.
+// TokenizeFile is like Tokenize but records filename in the position of every
+// token, so that diagnostics can point at file.ksm:line:col.
+func TokenizeFile(filename, input string) *Lexer {
+	return (&Lexer{input: input, filename: filename}).start()
+}
+
+// start reads the first character of the input, skipping a leading byte
+// order mark, and returns the lexer ready to produce tokens.
+func (l *Lexer) start() *Lexer {
+	l.line, l.column = 1, 1
+	l.getChar() // Initialize the current character and position of the lexer
+	if l.currentChar == byteOrderMark {
+		l.getChar()
+		l.column = 1
+	}
+	return l
+}
+
+// getChar advances the lexer to the next character in the input string,
+// decoding it from UTF-8. It updates the current character (currentChar), its
+// position (position, line and column) and the read position (readPosition).
+// If the read position is at or beyond the end of the input string, the
+// current character is set to eof. Invalid UTF-8 and NUL characters are
+// reported as errors.
 func (tokens *Lexer) getChar() { //readChar() advances the lexer to the next character in the input string
-	if tokens.readPosition >= len(tokens.input) {
-		tokens.currentChar = 0 // ASCII code -> NULL
+	if tokens.currentChar == '\n' {
+		tokens.line++
+		tokens.column = 1
 	} else {
-		tokens.currentChar = tokens.input[tokens.readPosition]
+		tokens.column += tokens.readPosition - tokens.position
 	}
 	tokens.position = tokens.readPosition
-	tokens.readPosition++
+
+	if tokens.reader != nil {
+		tokens.fill(tokens.readPosition + utf8.UTFMax)
+	}
+	if tokens.readPosition >= len(tokens.input) {
+		tokens.currentChar = eof
+		return
+	}
+
+	ch, width := rune(tokens.input[tokens.readPosition]), 1
+	switch {
+	case ch == 0:
+		tokens.addError(tokens.currentPosition(), InvalidEncoding, "illegal character NUL")
+	case ch >= utf8.RuneSelf:
+		ch, width = utf8.DecodeRuneInString(tokens.input[tokens.readPosition:])
+		if ch == utf8.RuneError && width == 1 {
+			tokens.addError(tokens.currentPosition(), InvalidEncoding, "illegal UTF-8 encoding")
+		} else if ch == byteOrderMark && tokens.base+tokens.position > 0 {
+			tokens.addError(tokens.currentPosition(), InvalidEncoding, "illegal byte order mark")
+		}
+	}
+	tokens.currentChar = ch
+	tokens.readPosition += width
+}
+
+// Errors returns the problems found in the input so far, such as unterminated
+// string literals, in the order they were found.
+func (l *Lexer) Errors() []Diagnostic {
+	return l.errors
 }
 
-func (l *Lexer) peekChar() byte {
+func (l *Lexer) addError(pos Position, code DiagnosticCode, format string, a ...interface{}) {
+	l.errors = append(l.errors, Diagnostic{Pos: pos, Code: code, Message: fmt.Sprintf(format, a...)})
+}
+
+// currentPosition returns the position of currentChar.
+func (l *Lexer) currentPosition() Position {
+	return Position{Filename: l.filename, Offset: l.base + l.position, Line: l.line, Column: l.column}
+}
+
+func (l *Lexer) peekChar() rune {
+	if l.reader != nil {
+		l.fill(l.readPosition + utf8.UTFMax)
+	}
 	if l.readPosition >= len(l.input) {
-		return 0 // End of input
+		return eof // End of input
+	}
+	ch := rune(l.input[l.readPosition])
+	if ch >= utf8.RuneSelf {
+		ch, _ = utf8.DecodeRuneInString(l.input[l.readPosition:])
+	}
+	return ch
+}
+
+// skipWhitespace skips whitespace and comments up to the start of the next
+// token. When the previous token can end a statement it stops early and
+// reports true at the first newline (including one inside a block comment)
+// or at the end of the input, where a semicolon must be inserted.
+func (l *Lexer) skipWhitespace() bool {
+	for {
+		switch {
+		case l.currentChar == '\n' || l.currentChar == eof:
+			if l.insertSemi {
+				return true
+			}
+			if l.currentChar == eof {
+				return false
+			}
+			l.getChar()
+		case l.currentChar == ' ' || l.currentChar == '\t' || l.currentChar == '\r':
+			l.getChar()
+		case l.currentChar == '/' && l.peekChar() == '/':
+			l.readLineComment()
+		case l.currentChar == '/' && l.peekChar() == '*':
+			if l.readBlockComment() && l.insertSemi {
+				return true
+			}
+		default:
+			return false
+		}
 	}
-	return l.input[l.readPosition]
 }
 
-func (l *Lexer) skipWhitespace() {
-	for l.currentChar == ' ' || l.currentChar == '\t' || l.currentChar == '\n' || l.currentChar == '\r' {
+// readNumber reads an integer, floating-point or imaginary literal using Go's
+// syntax: decimal, 0x hex, 0o octal and 0b binary integers, decimals with a
+// fraction and/or exponent, '_' digit separators and an 'i' imaginary suffix.
+// The literal is returned exactly as written; converting it to a value is left
+// to the parser.
+func (l *Lexer) readNumber() Token {
+	start := l.position
+	tokenType := INT
+
+	base := 10
+	if l.currentChar == '0' {
+		switch l.peekChar() {
+		case 'x', 'X':
+			base = 16
+		case 'o', 'O':
+			base = 8
+		case 'b', 'B':
+			base = 2
+		}
+		if base != 10 {
+			l.getChar()
+			l.getChar()
+		}
+	}
+
+	if !l.readDigits(base) && base != 10 {
+		l.addError(l.currentPosition(), InvalidNumber, "%s literal has no digits", baseName(base))
+	}
+
+	if base == 10 {
+		// A second '.' belongs to a range operator such as 1..10, not to the number.
+		if l.currentChar == '.' && l.peekChar() != '.' {
+			tokenType = FLOAT
+			l.getChar()
+			l.readDigits(10)
+		}
+		if l.currentChar == 'e' || l.currentChar == 'E' {
+			tokenType = FLOAT
+			l.getChar()
+			if l.currentChar == '+' || l.currentChar == '-' {
+				l.getChar()
+			}
+			if !l.readDigits(10) {
+				l.addError(l.currentPosition(), InvalidNumber, "exponent has no digits")
+			}
+		}
+	}
+
+	if l.currentChar == 'i' {
+		tokenType = IMAGINARY
 		l.getChar()
 	}
+
+	return newToken(tokenType, l.input[start:l.position])
 }
 
-func (l *Lexer) readNumber() string {
-	var number string
+// readDigits consumes the digits and '_' separators of a number in the given
+// base and reports whether it read at least one digit. Decimal digits that are
+// not valid in the base are consumed too, so they are reported instead of
+// starting a new token.
+func (l *Lexer) readDigits(base int) bool {
+	reported := false
+	digits := false
+	for IsHexDigit(l.currentChar) || l.currentChar == '_' {
+		if l.currentChar != '_' {
+			if digitValue(l.currentChar) >= base {
+				if base == 10 && !IsDigit(l.currentChar) {
+					break // e.g. the 'e' of an exponent or an identifier that follows
+				}
+				if !reported {
+					l.addError(l.currentPosition(), InvalidNumber, "invalid digit %q in %s literal", l.currentChar, baseName(base))
+					reported = true
+				}
+			}
+			digits = true
+		}
+		l.getChar()
+	}
+	return digits
+}
+
+func IsHexDigit(char rune) bool {
+	return IsDigit(char) || 'a' <= char && char <= 'f' || 'A' <= char && char <= 'F'
+}
+
+func digitValue(char rune) int {
+	switch {
+	case IsDigit(char):
+		return int(char - '0')
+	case 'a' <= char && char <= 'f':
+		return int(char - 'a' + 10)
+	case 'A' <= char && char <= 'F':
+		return int(char - 'A' + 10)
+	}
+	return 16
+}
+
+func baseName(base int) string {
+	switch base {
+	case 2:
+		return "binary"
+	case 8:
+		return "octal"
+	case 16:
+		return "hexadecimal"
+	}
+	return "decimal"
+}
+
+// readString reads a double-quoted string literal, decoding Go-style escape
+// sequences, and returns a STRING token holding the decoded value.
+// The lexer is left on the character after the closing quote.
+func (l *Lexer) readString() Token {
+	start := l.currentPosition()
+	begin := l.position + 1 // index of the first character of the value
+	var value strings.Builder
+	escaped := false // whether the value is being built in value, rather than sliced from the input
+
+	text := func() string {
+		if escaped {
+			return value.String()
+		}
+		return l.input[begin:l.position]
+	}
+
+	l.getChar() // skip the opening quote
+	for {
+		switch l.currentChar {
+		case '"':
+			tok := newToken(STRING, text())
+			l.getChar()
+			return tok
+		case '\n', eof:
+			l.addError(start, UnterminatedLiteral, "unterminated string literal")
+			return newToken(STRING, text())
+		case '\\':
+			if !escaped {
+				value.WriteString(l.input[begin:l.position])
+				escaped = true
+			}
+			if ch, multibyte, ok := l.readEscape('"'); ok {
+				if ch < utf8.RuneSelf || !multibyte {
+					value.WriteByte(byte(ch)) // \x and octal escapes denote single bytes in strings
+				} else {
+					value.WriteRune(ch)
+				}
+			}
+		default:
+			if escaped {
+				value.WriteRune(l.currentChar)
+			}
+			l.getChar()
+		}
+	}
+}
 
-	for IsDigit(byte(l.currentChar)) {
-		number += string(l.currentChar)
-		l.getChar() // read & fetch the character
+// readEscape decodes the escape sequence starting at the current backslash
+// and moves past it. multibyte is false for \x and octal escapes, which
+// denote a single byte inside strings. Invalid escapes are reported and
+// ok is false.
+func (l *Lexer) readEscape(quote byte) (ch rune, multibyte bool, ok bool) {
+	pos := l.currentPosition()
+	if l.reader != nil {
+		l.fill(l.position + len(`\U0010FFFF`))
+	}
+	ch, multibyte, tail, err := strconv.UnquoteChar(l.input[l.position:], quote)
+	if err != nil {
+		l.addError(pos, InvalidEscape, "invalid escape sequence \\%c", l.peekChar())
+		l.getChar() // skip the backslash; the next character is read as-is
+		return 0, false, false
 	}
 
-	if l.currentChar == '.' {
-		number += string(l.currentChar)
-		l.getChar() // read & fetch the character
+	for n := len(l.input) - l.position - len(tail); n > 0; n-- {
+		l.getChar()
+	}
+	return ch, multibyte, true
+}
+
+// readRune reads a single-quoted rune literal such as 'a', '\n' or '\”
+// and returns a RUNE token whose literal is the decoded character.
+func (l *Lexer) readRune() Token {
+	start := l.currentPosition()
+	var value []rune
 
-		for IsDigit(byte(l.currentChar)) {
-			number += string(l.currentChar)
+	l.getChar() // skip the opening quote
+	for l.currentChar != '\'' {
+		switch l.currentChar {
+		case '\n', eof:
+			l.addError(start, UnterminatedLiteral, "unterminated rune literal")
+			return newToken(RUNE, string(value))
+		case '\\':
+			if ch, _, ok := l.readEscape('\''); ok {
+				value = append(value, ch)
+			}
+		default:
+			value = append(value, l.currentChar)
+			l.getChar()
 		}
 	}
-	return number
+	l.getChar() // skip the closing quote
+
+	switch len(value) {
+	case 0:
+		l.addError(start, InvalidRune, "empty rune literal or unescaped ' in rune literal")
+		return newToken(RUNE, "")
+	case 1:
+		return newToken(RUNE, string(value))
+	default:
+		l.addError(start, InvalidRune, "more than one character in rune literal")
+		return newToken(RUNE, string(value[:1]))
+	}
+}
+
+// readRawString reads a backtick-quoted string. Raw strings may span several
+// lines and contain no escape sequences; carriage returns are dropped as in Go.
+func (l *Lexer) readRawString() Token {
+	start := l.currentPosition()
+	begin := l.position + 1 // index of the first character of the value
+
+	l.getChar() // skip the opening backtick
+	for l.currentChar != '`' {
+		if l.currentChar == eof {
+			l.addError(start, UnterminatedLiteral, "unterminated raw string literal")
+			break
+		}
+		l.getChar()
+	}
+	value := l.input[begin:l.position]
+	if strings.IndexByte(value, '\r') >= 0 {
+		value = strings.ReplaceAll(value, "\r", "")
+	}
+	if l.currentChar == '`' {
+		l.getChar() // skip the closing backtick
+	}
+	return newToken(STRING, value)
 }
 
-func IsDigit(char byte) bool {
+// IsDigit reports whether char is an ASCII decimal digit, the only digits
+// allowed in number literals.
+func IsDigit(char rune) bool {
 	return '0' <= char && char <= '9'
 
 }
 
+// GetNextToken skips any whitespace and returns the next token in the input,
+// with its start and end positions filled in.
+//
+// As in Go, semicolons are inserted automatically: a newline, or the end of
+// the input, that follows a token which can end a statement (see
+// endsStatement) is returned as a SEMI_COLON token with the literal "\n".
+// Newline-terminated statements therefore parse exactly like
+// semicolon-terminated ones.
+//
+// Problems are recorded in Errors rather than returned as tokens: characters
+// that cannot start a token are skipped, so ILLEGAL is never returned.
 func (l *Lexer) GetNextToken() Token {
+	l.discard()
+
 	var tok Token
+	var start Position
+	for {
+		autoSemi := l.skipWhitespace()
+		start = l.currentPosition()
+		if autoSemi {
+			tok = newToken(SEMI_COLON, "\n")
+			if l.currentChar == '\n' {
+				l.getChar()
+			}
+			break
+		}
+		// Characters that cannot start a token are reported and skipped.
+		if tok = l.scanToken(); tok.Type != ILLEGAL {
+			break
+		}
+	}
+	tok.Start = start
+	tok.End = l.currentPosition()
+	tok.Comments, l.comments = l.comments, nil
+	l.insertSemi = endsStatement(tok.Type)
+	return tok
+}
+
+// endsStatement reports whether a newline after a token of type t ends the
+// statement: identifiers, literals, this, super, closing brackets, return,
+// break, continue, ++ and --.
+func endsStatement(t TokenType) bool {
+	switch t {
+	case IDENTIFIER, INT, FLOAT, IMAGINARY, RUNE, STRING, TRUE, FALSE, NULL, THIS, SUPER,
+		CLOSE_PARENTHESES, CLOSE_BRACKET, CLOSE_CURLY,
+		RETURN, BREAK, CONTINUE, PLUS_PLUS, MINUS_MINUS:
+		return true
+	}
+	return false
+}
 
-	l.skipWhitespace()
+func (l *Lexer) scanToken() Token {
+	var tok Token
 
 	switch l.currentChar {
 	case '[':
-		tok = newToken(OPEN_BRACKET, string(l.currentChar))
+		tok = newToken(OPEN_BRACKET, "[")
 	case ']':
-		tok = newToken(CLOSE_BRACKET, string(l.currentChar))
+		tok = newToken(CLOSE_BRACKET, "]")
 	case '{':
-		tok = newToken(OPEN_CURLY, string(l.currentChar))
+		tok = newToken(OPEN_CURLY, "{")
 	case '}':
-		tok = newToken(CLOSE_CURLY, string(l.currentChar))
+		tok = newToken(CLOSE_CURLY, "}")
 	case '(':
-		tok = newToken(OPEN_PARENTHESES, string(l.currentChar))
+		tok = newToken(OPEN_PARENTHESES, "(")
 	case ')':
-		tok = newToken(CLOSE_PARENTHESES, string(l.currentChar))
+		tok = newToken(CLOSE_PARENTHESES, ")")
 	case '=':
 		if l.peekChar() == '=' {
-			ch := l.currentChar
 			l.getChar()
-			tok = newToken(EQUALS, string(ch)+string(l.currentChar))
+			tok = newToken(EQUALS, "==")
 		} else {
-			tok = newToken(ASSIGNMENT, string(l.currentChar))
+			tok = newToken(ASSIGNMENT, "=")
 		}
 	case '+':
 		if l.peekChar() == '+' {
@@ -308,7 +895,7 @@ func (l *Lexer) GetNextToken() Token {
 			l.getChar()
 			tok = newToken(PLUS_EQUALS, "+=")
 		} else {
-			tok = newToken(PLUS, string(l.currentChar))
+			tok = newToken(PLUS, "+")
 		}
 	case '-':
 		if l.peekChar() == '-' {
@@ -318,85 +905,119 @@ func (l *Lexer) GetNextToken() Token {
 			l.getChar()
 			tok = newToken(MINUS_EQUALS, "-=")
 		} else {
-			tok = newToken(DASH, string(l.currentChar))
+			tok = newToken(DASH, "-")
 		}
 	case '!':
 		if l.peekChar() == '=' {
-			ch := l.currentChar
 			l.getChar()
-			tok = newToken(NOT_EQUALS, string(ch)+string(l.currentChar))
+			tok = newToken(NOT_EQUALS, "!=")
 		} else {
-			tok = newToken(BANG, string(l.currentChar))
+			tok = newToken(BANG, "!")
 		}
 	case '*':
 		if l.peekChar() == '=' {
 			l.getChar()
 			tok = newToken(STAR_EQUALS, "*=")
 		} else {
-			tok = newToken(ASTERISK, string(l.currentChar))
+			tok = newToken(ASTERISK, "*")
 		}
 	case '/':
 		if l.peekChar() == '=' {
 			l.getChar()
 			tok = newToken(SLASH_EQUALS, "/=")
 		} else {
-			tok = newToken(SLASH, string(l.currentChar))
+			tok = newToken(SLASH, "/")
 		}
+	case '%':
+		tok = newToken(PERCENT, "%")
 	case '<':
 		if l.peekChar() == '=' {
 			l.getChar()
 			tok = newToken(LESS_EQUAL, "<=")
+		} else if l.peekChar() == '<' {
+			l.getChar()
+			tok = newToken(SHIFT_LEFT, "<<")
 		} else {
-			tok = newToken(LESS, string(l.currentChar))
+			tok = newToken(LESS, "<")
 		}
 	case '>':
 		if l.peekChar() == '=' {
 			l.getChar()
 			tok = newToken(GREATER_EQUALS, ">=")
+		} else if l.peekChar() == '>' {
+			l.getChar()
+			tok = newToken(SHIFT_RIGHT, ">>")
 		} else {
-			tok = newToken(GREATER, string(l.currentChar))
+			tok = newToken(GREATER, ">")
 		}
 	case '|':
 		if l.peekChar() == '|' {
 			l.getChar()
 			tok = newToken(OR, "||")
+		} else {
+			tok = newToken(PIPE, "|")
 		}
 	case '&':
 		if l.peekChar() == '&' {
 			l.getChar()
 			tok = newToken(AND, "&&")
+		} else if l.peekChar() == '^' {
+			l.getChar()
+			tok = newToken(AND_NOT, "&^")
+		} else {
+			tok = newToken(AMPERSAND, "&")
 		}
+	case '^':
+		tok = newToken(CARET, "^")
 	case '.':
-		if l.peekChar() == '.' {
+		if IsDigit(l.peekChar()) {
+			return l.readNumber() // .5 is a float
+		}
+		if l.peekChar() != '.' {
+			tok = newToken(DOT, ".")
+			break
+		}
+		l.getChar()
+		switch l.peekChar() {
+		case '.':
 			l.getChar()
-			if l.peekChar() == '.' {
-				l.getChar()
-				tok = newToken(DOT_DOT, "...")
-			} else {
-				tok = newToken(DOT, string(l.currentChar))
-			}
-		} else {
-			tok = newToken(DOT, string(l.currentChar))
+			tok = newToken(ELLIPSIS, "...")
+		case '<':
+			l.getChar()
+			tok = newToken(DOT_DOT_LESS, "..<")
+		default:
+			tok = newToken(DOT_DOT, "..")
 		}
 	case ';':
-		tok = newToken(SEMI_COLON, string(l.currentChar))
+		tok = newToken(SEMI_COLON, ";")
 	case ':':
-		tok = newToken(COLON, string(l.currentChar))
+		if l.peekChar() == '=' {
+			l.getChar()
+			tok = newToken(COLON_EQUALS, ":=")
+		} else {
+			tok = newToken(COLON, ":")
+		}
 	case '?':
-		tok = newToken(QUESTION, string(l.currentChar))
+		tok = newToken(QUESTION, "?")
 	case ',':
-		tok = newToken(COMMA, string(l.currentChar))
-	case 0:
-		tok = newToken(EOF, "")
+		tok = newToken(COMMA, ",")
+	case '"':
+		return l.readString()
+	case '`':
+		return l.readRawString()
+	case '\'':
+		return l.readRune()
+	case eof:
+		return newToken(EOF, "") // Stay on EOF so that every further call returns EOF too
 	default:
-		if IsLetter(l.currentChar) || l.currentChar == '_' {
+		if IsLetter(l.currentChar) {
 			ident := l.readIdentifier()
 			tok = NewToken(LookupIdentifier(ident), ident) // Use LookupIdentifier here
 			return tok                                     // Return here to prevent getting the next character too early
 		} else if IsDigit(l.currentChar) {
-			tok = newToken(INT, l.readNumber())
-			return tok // Return here to prevent getting the next character too early
+			return l.readNumber() // Return here to prevent getting the next character too early
 		} else {
+			l.invalidCharacter()
 			tok = newToken(ILLEGAL, string(l.currentChar))
 		}
 	}
@@ -405,6 +1026,35 @@ func (l *Lexer) GetNextToken() Token {
 	return tok
 }
 
+// invalidCharacter reports currentChar, which cannot start a token. NUL,
+// malformed UTF-8 and byte order marks have already been reported by getChar.
+func (l *Lexer) invalidCharacter() {
+	switch l.currentChar {
+	case 0, byteOrderMark:
+	case utf8.RuneError:
+		if l.readPosition-l.position != 1 {
+			l.unexpectedCharacter("") // a well-formed U+FFFD
+		}
+	case '“', '”':
+		l.unexpectedCharacter(`did you mean '"'?`)
+	case '‘', '’':
+		l.unexpectedCharacter(`did you mean "'"?`)
+	default:
+		l.unexpectedCharacter("")
+	}
+}
+
+// unexpectedCharacter reports currentChar as out of place, with an optional
+// suggested fix.
+func (l *Lexer) unexpectedCharacter(suggestion string) {
+	l.errors = append(l.errors, Diagnostic{
+		Pos:        l.currentPosition(),
+		Code:       InvalidCharacter,
+		Message:    fmt.Sprintf("unexpected character %q", l.currentChar),
+		Suggestion: suggestion,
+	})
+}
+
 func newToken(tokenType TokenType, currentChar string) Token {
 	return Token{
 		Type:    tokenType,
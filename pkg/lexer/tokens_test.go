@@ -33,8 +33,56 @@ func TestTokenize(t *testing.T) {
 
 	for i, tt := range expectedTokens {
 		tokens := ksm.GetNextToken()
-		if string(tokens.Type) != string(tt.expectedType) || tokens.Literal != tt.expectedLiteral {
+		if tokens.Type != tt.expectedType || tokens.Literal != tt.expectedLiteral {
 			t.Fatalf("Expected token %d to be {%v, %s}, but got {%v, %s}", i, tt.expectedType, tt.expectedLiteral, tokens.Type, tokens.Literal)
 		}
 	}
 }
+
+func TestLookupIdentifier(t *testing.T) {
+	tests := []struct {
+		ident    string
+		expected lexer.TokenType
+	}{
+		{"fn", lexer.FN}, {"function", lexer.FN}, {"let", lexer.LET}, {"const", lexer.CONST},
+		{"class", lexer.CLASS}, {"new", lexer.NEW}, {"import", lexer.IMPORT}, {"from", lexer.FROM},
+		{"if", lexer.IF}, {"else", lexer.ELSE}, {"foreach", lexer.FOREACH}, {"while", lexer.WHILE},
+		{"for", lexer.FOR}, {"export", lexer.EXPORT}, {"typeof", lexer.TYPEOF}, {"in", lexer.IN},
+		{"return", lexer.RETURN}, {"break", lexer.BREAK}, {"continue", lexer.CONTINUE},
+		{"null", lexer.NULL}, {"true", lexer.TRUE}, {"false", lexer.FALSE},
+		{"struct", lexer.STRUCT_TYPE}, {"var", lexer.VAR}, {"type", lexer.TYPE}, {"or", lexer.OR},
+		{"and", lexer.AND}, {"extends", lexer.EXTENDS}, {"this", lexer.THIS}, {"super", lexer.SUPER},
+		{"instanceof", lexer.INSTANCEOF}, {"constructor", lexer.IDENTIFIER},
+		{"f", lexer.IDENTIFIER}, {"rune", lexer.IDENTIFIER}, {"int", lexer.IDENTIFIER},
+		{"boolean", lexer.IDENTIFIER}, {"bool", lexer.IDENTIFIER}, {"lets", lexer.IDENTIFIER},
+		{"If", lexer.IDENTIFIER}, {"functions", lexer.IDENTIFIER}, {"", lexer.IDENTIFIER},
+	}
+
+	for _, tt := range tests {
+		if got := lexer.LookupIdentifier(tt.ident); got != tt.expected {
+			t.Errorf("LookupIdentifier(%q) = %s, expected %s", tt.ident, got, tt.expected)
+		}
+	}
+}
+
+func TestTokenTypeString(t *testing.T) {
+	tests := map[lexer.TokenType]string{
+		lexer.ILLEGAL:          "ILLEGAL",
+		lexer.EOF:              "EOF",
+		lexer.IDENTIFIER:       "IDENTIFIER",
+		lexer.OPEN_PARENTHESES: "OPEN_PARENTHESES",
+		lexer.FN:               "FUNCTION",
+		lexer.CONTINUE:         "CONTINUE",
+		lexer.TokenType(250):   "TokenType(250)",
+	}
+	for tokenType, expected := range tests {
+		if got := tokenType.String(); got != expected {
+			t.Errorf("expected %q, got %q", expected, got)
+		}
+	}
+
+	var zero lexer.Token
+	if zero.Type != lexer.ILLEGAL {
+		t.Errorf("the zero token type should be ILLEGAL, got %s", zero.Type)
+	}
+}
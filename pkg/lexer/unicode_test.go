@@ -0,0 +1,108 @@
+package lexer_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/lexer"
+)
+
+func TestUnicodeIdentifiers(t *testing.T) {
+	input := `let café = "Habari, dunia! 🌍"; let Jina2 = café; let _mti = 日本語; let x١ = 1;`
+
+	expected := []struct {
+		expectedType    lexer.TokenType
+		expectedLiteral string
+	}{
+		{lexer.LET, "let"},
+		{lexer.IDENTIFIER, "café"},
+		{lexer.ASSIGNMENT, "="},
+		{lexer.STRING, "Habari, dunia! 🌍"},
+		{lexer.SEMI_COLON, ";"},
+		{lexer.LET, "let"},
+		{lexer.IDENTIFIER, "Jina2"},
+		{lexer.ASSIGNMENT, "="},
+		{lexer.IDENTIFIER, "café"},
+		{lexer.SEMI_COLON, ";"},
+		{lexer.LET, "let"},
+		{lexer.IDENTIFIER, "_mti"},
+		{lexer.ASSIGNMENT, "="},
+		{lexer.IDENTIFIER, "日本語"},
+		{lexer.SEMI_COLON, ";"},
+		{lexer.LET, "let"},
+		{lexer.IDENTIFIER, "x١"},
+		{lexer.ASSIGNMENT, "="},
+		{lexer.INT, "1"},
+		{lexer.SEMI_COLON, ";"},
+		{lexer.EOF, ""},
+	}
+
+	lex := lexer.Tokenize(input)
+	for i, tt := range expected {
+		tok := lex.GetNextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong token. expected=%q (%s), got=%q (%s)",
+				i, tt.expectedLiteral, tt.expectedType, tok.Literal, tok.Type)
+		}
+	}
+	if len(lex.Errors()) != 0 {
+		t.Errorf("unexpected errors: %v", lex.Errors())
+	}
+}
+
+func TestUnicodePositions(t *testing.T) {
+	input := "let é = 1;\n€"
+
+	lex := lexer.Tokenize(input)
+	lex.GetNextToken() // let
+	ident := lex.GetNextToken()
+	if ident.Start.Column != 5 || ident.End.Column != 7 || ident.End.Offset != 6 {
+		t.Errorf("identifier position wrong. start=%+v end=%+v", ident.Start, ident.End)
+	}
+	assign := lex.GetNextToken()
+	if assign.Start.Column != 8 {
+		t.Errorf("position after identifier wrong. got=%+v", assign.Start)
+	}
+
+	lex.GetNextToken() // 1
+	lex.GetNextToken() // ;
+	if tok := lex.GetNextToken(); tok.Type != lexer.EOF {
+		t.Errorf("expected € to be skipped, got %s %q", tok.Type, tok.Literal)
+	}
+	errors := lex.Errors()
+	if len(errors) != 1 || errors[0].Pos.Line != 2 || errors[0].Pos.Column != 1 {
+		t.Errorf("expected one error at 2:1, got %v", errors)
+	}
+}
+
+func TestInvalidUTF8(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"let x = \xff;", "1:9: illegal UTF-8 encoding"},
+		{"let s = \"ab\xc3\";", "1:12: illegal UTF-8 encoding"},
+		{"let x\x00 = 1;", "1:6: illegal character NUL"},
+		{"let x = 1; \uFEFF", "1:12: illegal byte order mark"},
+	}
+
+	for i, tt := range tests {
+		lex := lexer.Tokenize(tt.input)
+		for tok := lex.GetNextToken(); tok.Type != lexer.EOF; tok = lex.GetNextToken() {
+		}
+		errors := lex.Errors()
+		if len(errors) != 1 || errors[0].Error() != tt.expectedError {
+			t.Errorf("tests[%d] - expected error %q, got %v", i, tt.expectedError, errors)
+		}
+	}
+}
+
+func TestLeadingByteOrderMarkIsIgnored(t *testing.T) {
+	lex := lexer.Tokenize("\uFEFFlet")
+	tok := lex.GetNextToken()
+	if tok.Type != lexer.LET || tok.Start.Column != 1 || tok.Start.Offset != 3 {
+		t.Errorf("expected LET at column 1 offset 3, got %s at %+v", tok.Type, tok.Start)
+	}
+	if len(lex.Errors()) != 0 {
+		t.Errorf("unexpected errors: %v", lex.Errors())
+	}
+}
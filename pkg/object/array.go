@@ -1 +1,53 @@
-package object
\ No newline at end of file
+package object
+
+import (
+	"bytes"
+	"strings"
+)
+
+type Array struct {
+	Elements []Object
+}
+
+func (a *Array) Type() ObjectType { return ARRAY_OBJ }
+func (a *Array) Inspect() string {
+	var out bytes.Buffer
+
+	elements := make([]string, 0, len(a.Elements))
+	for _, e := range a.Elements {
+		elements = append(elements, e.Inspect())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+	return out.String()
+}
+
+func (a *Array) Length() int {
+	return len(a.Elements)
+}
+
+// First returns the first element, or NULL for an empty array.
+func (a *Array) First() Object {
+	if len(a.Elements) == 0 {
+		return NULL
+	}
+	return a.Elements[0]
+}
+
+// Last returns the last element, or NULL for an empty array.
+func (a *Array) Last() Object {
+	if len(a.Elements) == 0 {
+		return NULL
+	}
+	return a.Elements[len(a.Elements)-1]
+}
+
+// Get returns the element at index, reporting false when it is out of range.
+func (a *Array) Get(index int) (Object, bool) {
+	if index < 0 || index >= len(a.Elements) {
+		return nil, false
+	}
+	return a.Elements[index], true
+}
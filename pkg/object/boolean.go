@@ -1 +1,42 @@
-package object
\ No newline at end of file
+package object
+
+import "strconv"
+
+var (
+	TRUE  = &Boolean{Value: true}
+	FALSE = &Boolean{Value: false}
+)
+
+type Boolean struct {
+	Value bool
+}
+
+func (b *Boolean) Type() ObjectType { return BOOLEAN_OBJ }
+func (b *Boolean) Inspect() string  { return strconv.FormatBool(b.Value) }
+func (b *Boolean) HashKey() HashKey {
+	var value uint64
+	if b.Value {
+		value = 1
+	}
+	return HashKey{Type: b.Type(), Value: value}
+}
+
+func (b *Boolean) Not() *Boolean {
+	return NativeBool(!b.Value)
+}
+
+func (b *Boolean) And(other *Boolean) *Boolean {
+	return NativeBool(b.Value && other.Value)
+}
+
+func (b *Boolean) Or(other *Boolean) *Boolean {
+	return NativeBool(b.Value || other.Value)
+}
+
+// NativeBool returns the TRUE or FALSE singleton matching input.
+func NativeBool(input bool) *Boolean {
+	if input {
+		return TRUE
+	}
+	return FALSE
+}
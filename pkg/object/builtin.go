@@ -0,0 +1,13 @@
+package object
+
+// BuiltinFunction is the Go implementation of a built-in function.
+type BuiltinFunction func(args ...Object) Object
+
+// Builtin is a function provided by the interpreter, such as len.
+type Builtin struct {
+	Name string
+	Fn   BuiltinFunction
+}
+
+func (b *Builtin) Type() ObjectType { return BUILTIN_OBJ }
+func (b *Builtin) Inspect() string  { return "builtin function " + b.Name }
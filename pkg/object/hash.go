@@ -1 +1,122 @@
-package object
\ No newline at end of file
+package object
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// HashKey identifies a Hashable value inside a Hash. Two objects of the same
+// type and value always produce the same HashKey.
+type HashKey struct {
+	Type  ObjectType
+	Value uint64
+}
+
+type HashPair struct {
+	Key   Object
+	Value Object
+}
+
+// Hash is a key-value store that remembers the order keys were first set in,
+// so that printing and iterating over it is deterministic. Keys with the same
+// HashKey share a bucket and are told apart by comparing the keys themselves.
+type Hash struct {
+	buckets map[HashKey][]int // indexes into pairs
+	pairs   []HashPair
+}
+
+func NewHash() *Hash {
+	return &Hash{buckets: make(map[HashKey][]int)}
+}
+
+func (h *Hash) Type() ObjectType { return HASH_OBJ }
+func (h *Hash) Inspect() string {
+	var out bytes.Buffer
+
+	pairs := make([]string, 0, len(h.pairs))
+	for _, pair := range h.pairs {
+		pairs = append(pairs, pair.Key.Inspect()+": "+pair.Value.Inspect())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
+// Get returns the value stored under key.
+func (h *Hash) Get(key Object) (Object, bool) {
+	hashable, ok := key.(Hashable)
+	if !ok {
+		return nil, false
+	}
+	return h.get(hashable.HashKey(), key)
+}
+
+// Set stores value under key, failing if key cannot be hashed.
+func (h *Hash) Set(key, value Object) error {
+	hashable, ok := key.(Hashable)
+	if !ok {
+		return fmt.Errorf("unusable as hash key: %s", key.Type())
+	}
+	h.set(hashable.HashKey(), key, value)
+	return nil
+}
+
+func (h *Hash) get(hashKey HashKey, key Object) (Object, bool) {
+	if i, ok := h.find(hashKey, key); ok {
+		return h.pairs[i].Value, true
+	}
+	return nil, false
+}
+
+func (h *Hash) set(hashKey HashKey, key, value Object) {
+	if i, ok := h.find(hashKey, key); ok {
+		h.pairs[i] = HashPair{Key: key, Value: value}
+		return
+	}
+	h.buckets[hashKey] = append(h.buckets[hashKey], len(h.pairs))
+	h.pairs = append(h.pairs, HashPair{Key: key, Value: value})
+}
+
+// find returns the index of the pair whose key equals key in the bucket for
+// hashKey.
+func (h *Hash) find(hashKey HashKey, key Object) (int, bool) {
+	for _, i := range h.buckets[hashKey] {
+		if sameKey(h.pairs[i].Key, key) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// sameKey reports whether a and b, which have the same HashKey, are the same
+// key. Only strings can collide: the HashKey of every other key type holds
+// its whole value.
+func sameKey(a, b Object) bool {
+	as, ok := a.(*String)
+	if !ok {
+		return true
+	}
+	bs, ok := b.(*String)
+	return ok && as.Value == bs.Value
+}
+
+// Keys returns the keys in insertion order.
+func (h *Hash) Keys() []Object {
+	keys := make([]Object, 0, len(h.pairs))
+	for _, pair := range h.pairs {
+		keys = append(keys, pair.Key)
+	}
+	return keys
+}
+
+// Pairs returns the key-value pairs in insertion order.
+func (h *Hash) Pairs() []HashPair {
+	return append([]HashPair(nil), h.pairs...)
+}
+
+func (h *Hash) Length() int {
+	return len(h.pairs)
+}
@@ -1 +1,18 @@
-package object
\ No newline at end of file
+package object
+
+var NULL = &Null{}
+
+type Null struct{}
+
+func (n *Null) Type() ObjectType { return NULL_OBJ }
+func (n *Null) Inspect() string  { return "null" }
+
+// IsNull reports whether obj is the absence of a value.
+func IsNull(obj Object) bool {
+	return obj == nil || obj == Object(NULL)
+}
+
+// IsNotNull is the inverse of IsNull.
+func IsNotNull(obj Object) bool {
+	return !IsNull(obj)
+}
@@ -1 +1,82 @@
-package object
\ No newline at end of file
+package object
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Integer struct {
+	Value int64
+}
+
+func (i *Integer) Type() ObjectType { return INTEGER_OBJ }
+func (i *Integer) Inspect() string  { return strconv.FormatInt(i.Value, 10) }
+func (i *Integer) HashKey() HashKey {
+	return HashKey{Type: i.Type(), Value: uint64(i.Value)}
+}
+
+func (i *Integer) Add(other *Integer) *Integer {
+	return &Integer{Value: i.Value + other.Value}
+}
+
+func (i *Integer) Subtract(other *Integer) *Integer {
+	return &Integer{Value: i.Value - other.Value}
+}
+
+func (i *Integer) Multiply(other *Integer) *Integer {
+	return &Integer{Value: i.Value * other.Value}
+}
+
+type Float struct {
+	Value float64
+}
+
+func (f *Float) Type() ObjectType { return FLOAT_OBJ }
+
+// Inspect always prints a decimal point or exponent so a whole-valued float
+// can still be told apart from an Integer.
+func (f *Float) Inspect() string {
+	s := strconv.FormatFloat(f.Value, 'g', -1, 64)
+	if strings.ContainsAny(s, ".eIN") {
+		return s
+	}
+	return s + ".0"
+}
+
+func (f *Float) HashKey() HashKey {
+	return HashKey{Type: f.Type(), Value: math.Float64bits(f.Value)}
+}
+
+func (f *Float) Add(other *Float) *Float {
+	return &Float{Value: f.Value + other.Value}
+}
+
+func (f *Float) Subtract(other *Float) *Float {
+	return &Float{Value: f.Value - other.Value}
+}
+
+func (f *Float) Multiply(other *Float) *Float {
+	return &Float{Value: f.Value * other.Value}
+}
+
+// Complex is the value of an imaginary literal such as 2.5i, or of arithmetic
+// involving one.
+type Complex struct {
+	Value complex128
+}
+
+func (c *Complex) Type() ObjectType { return COMPLEX_OBJ }
+func (c *Complex) Inspect() string  { return strconv.FormatComplex(c.Value, 'g', -1, 128) }
+
+func (c *Complex) Add(other *Complex) *Complex {
+	return &Complex{Value: c.Value + other.Value}
+}
+
+func (c *Complex) Subtract(other *Complex) *Complex {
+	return &Complex{Value: c.Value - other.Value}
+}
+
+func (c *Complex) Multiply(other *Complex) *Complex {
+	return &Complex{Value: c.Value * other.Value}
+}
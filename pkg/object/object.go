@@ -1 +1,81 @@
-package object
\ No newline at end of file
+package object
+
+import "fmt"
+
+type ObjectType string
+
+const (
+	INTEGER_OBJ      = "INTEGER"
+	FLOAT_OBJ        = "FLOAT"
+	COMPLEX_OBJ      = "COMPLEX"
+	STRING_OBJ       = "STRING"
+	RUNE_OBJ         = "RUNE"
+	BOOLEAN_OBJ      = "BOOLEAN"
+	NULL_OBJ         = "NULL"
+	ARRAY_OBJ        = "ARRAY"
+	HASH_OBJ         = "HASH"
+	RANGE_OBJ        = "RANGE"
+	STRUCT_OBJ       = "STRUCT"
+	TYPE_OBJ         = "TYPE"
+	CLASS_OBJ        = "CLASS"
+	INSTANCE_OBJ     = "INSTANCE"
+	FUNCTION_OBJ     = "FUNCTION"
+	BUILTIN_OBJ      = "BUILTIN"
+	RETURN_VALUE_OBJ = "RETURN_VALUE"
+	BREAK_OBJ        = "BREAK"
+	CONTINUE_OBJ     = "CONTINUE"
+	ERROR_OBJ        = "ERROR"
+)
+
+// Object is the interface every runtime value produced by the interpreter implements.
+type Object interface {
+	Type() ObjectType
+	Inspect() string
+}
+
+// Hashable is implemented by the objects that can be used as Hash keys.
+type Hashable interface {
+	Object
+	HashKey() HashKey
+}
+
+// ReturnValue wraps the value of a return statement so the evaluator can
+// unwind nested statements until it reaches the enclosing program or function.
+type ReturnValue struct {
+	Value Object
+}
+
+func (rv *ReturnValue) Type() ObjectType { return RETURN_VALUE_OBJ }
+func (rv *ReturnValue) Inspect() string  { return rv.Value.Inspect() }
+
+// Break is the result of a break statement. Like a ReturnValue it unwinds
+// the statements around it, up to the loop named by Label or, if Label is
+// empty, the innermost loop.
+type Break struct {
+	Label string
+}
+
+func (b *Break) Type() ObjectType { return BREAK_OBJ }
+func (b *Break) Inspect() string  { return "break" }
+
+// Continue is the result of a continue statement. It unwinds the statements
+// around it up to a loop in the same way as Break.
+type Continue struct {
+	Label string
+}
+
+func (c *Continue) Type() ObjectType { return CONTINUE_OBJ }
+func (c *Continue) Inspect() string  { return "continue" }
+
+// Error is a runtime error. It stops evaluation the same way a ReturnValue does.
+type Error struct {
+	Message string
+}
+
+func (e *Error) Type() ObjectType { return ERROR_OBJ }
+func (e *Error) Inspect() string  { return "ERROR: " + e.Message }
+
+// NewError builds an Error object from a format string.
+func NewError(format string, a ...interface{}) *Error {
+	return &Error{Message: fmt.Sprintf(format, a...)}
+}
@@ -0,0 +1,175 @@
+package object
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStringHashKey(t *testing.T) {
+	hello1 := &String{Value: "Hello World"}
+	hello2 := &String{Value: "Hello World"}
+	diff1 := &String{Value: "My name is johnny"}
+	diff2 := &String{Value: "My name is johnny"}
+
+	if hello1.HashKey() != hello2.HashKey() {
+		t.Errorf("strings with same content have different hash keys")
+	}
+	if diff1.HashKey() != diff2.HashKey() {
+		t.Errorf("strings with same content have different hash keys")
+	}
+	if hello1.HashKey() == diff1.HashKey() {
+		t.Errorf("strings with different content have same hash keys")
+	}
+}
+
+func TestHashKeysDoNotCollideAcrossTypes(t *testing.T) {
+	one := &Integer{Value: 1}
+	if one.HashKey() == TRUE.HashKey() {
+		t.Errorf("1 and true have the same hash key")
+	}
+}
+
+func TestHashGetSetKeys(t *testing.T) {
+	hash := NewHash()
+	hash.Set(&String{Value: "name"}, &String{Value: "World"})
+	hash.Set(&String{Value: "age"}, &Integer{Value: 2024})
+	hash.Set(&String{Value: "name"}, &String{Value: "Kisumu"})
+
+	if hash.Length() != 2 {
+		t.Fatalf("hash.Length() wrong. got=%d", hash.Length())
+	}
+	val, ok := hash.Get(&String{Value: "name"})
+	if !ok || val.Inspect() != `"Kisumu"` {
+		t.Errorf("hash.Get(name) wrong. got=%v (%t)", val, ok)
+	}
+	if _, ok := hash.Get(&String{Value: "missing"}); ok {
+		t.Errorf("hash.Get(missing) should not be found")
+	}
+	if err := hash.Set(&Array{}, NULL); err == nil {
+		t.Errorf("expected error when using an array as key")
+	}
+	if hash.Inspect() != `{"name": "Kisumu", "age": 2024}` {
+		t.Errorf("hash.Inspect() wrong. got=%s", hash.Inspect())
+	}
+}
+
+func TestHashKeyCollision(t *testing.T) {
+	// Force two different strings into the same bucket.
+	bucket := HashKey{Type: STRING_OBJ, Value: 1}
+	a, b := &String{Value: "a"}, &String{Value: "b"}
+
+	hash := NewHash()
+	hash.set(bucket, a, &Integer{Value: 1})
+	hash.set(bucket, b, &Integer{Value: 2})
+	hash.set(bucket, &String{Value: "a"}, &Integer{Value: 3})
+
+	if hash.Length() != 2 {
+		t.Fatalf("hash.Length() wrong. got=%d", hash.Length())
+	}
+	if val, ok := hash.get(bucket, a); !ok || val.Inspect() != "3" {
+		t.Errorf("hash.get(a) wrong. got=%v (%t)", val, ok)
+	}
+	if val, ok := hash.get(bucket, b); !ok || val.Inspect() != "2" {
+		t.Errorf("hash.get(b) wrong. got=%v (%t)", val, ok)
+	}
+	if _, ok := hash.get(bucket, &String{Value: "c"}); ok {
+		t.Errorf("hash.get(c) should not be found")
+	}
+	if hash.Inspect() != `{"a": 3, "b": 2}` {
+		t.Errorf("hash.Inspect() wrong. got=%s", hash.Inspect())
+	}
+}
+
+func TestStringMethods(t *testing.T) {
+	s := &String{Value: "habari"}
+	if s.Length() != 6 {
+		t.Errorf("Length() wrong. got=%d", s.Length())
+	}
+	sub, ok := s.Substring(1, 4)
+	if !ok || sub.Value != "aba" {
+		t.Errorf("Substring(1, 4) wrong. got=%v", sub)
+	}
+	if _, ok := s.Substring(4, 10); ok {
+		t.Errorf("Substring(4, 10) should be out of range")
+	}
+	if got := s.Concat(&String{Value: " yako"}).Value; got != "habari yako" {
+		t.Errorf("Concat wrong. got=%q", got)
+	}
+}
+
+func TestArrayMethods(t *testing.T) {
+	empty := &Array{}
+	if empty.First() != NULL || empty.Last() != NULL {
+		t.Errorf("First/Last of empty array should be NULL")
+	}
+
+	arr := &Array{Elements: []Object{&Integer{Value: 1}, &Integer{Value: 2}, &Integer{Value: 3}}}
+	if arr.Length() != 3 {
+		t.Errorf("Length() wrong. got=%d", arr.Length())
+	}
+	if arr.First().Inspect() != "1" || arr.Last().Inspect() != "3" {
+		t.Errorf("First/Last wrong. got=%s, %s", arr.First().Inspect(), arr.Last().Inspect())
+	}
+	if _, ok := arr.Get(3); ok {
+		t.Errorf("Get(3) should be out of range")
+	}
+	if arr.Inspect() != "[1, 2, 3]" {
+		t.Errorf("Inspect() wrong. got=%s", arr.Inspect())
+	}
+}
+
+func TestNumberMethods(t *testing.T) {
+	a, b := &Integer{Value: 6}, &Integer{Value: 4}
+	if a.Add(b).Value != 10 || a.Subtract(b).Value != 2 || a.Multiply(b).Value != 24 {
+		t.Errorf("integer arithmetic wrong")
+	}
+	f := &Float{Value: 2}
+	if f.Inspect() != "2.0" {
+		t.Errorf("Float.Inspect() wrong. got=%s", f.Inspect())
+	}
+	if f.Multiply(&Float{Value: 1.5}).Value != 3 {
+		t.Errorf("float multiply wrong")
+	}
+}
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		r       *Range
+		inspect string
+		length  int
+	}{
+		{&Range{Start: 1, End: 5, Inclusive: true}, "1..5", 5},
+		{&Range{Start: 1, End: 5}, "1..<5", 4},
+		{&Range{Start: -2, End: 2}, "-2..<2", 4},
+		{&Range{Start: 5, End: 5}, "5..<5", 0},
+		{&Range{Start: 5, End: 1, Inclusive: true}, "5..1", 0},
+		{&Range{Start: math.MinInt64, End: math.MaxInt64, Inclusive: true}, "-9223372036854775808..9223372036854775807", math.MaxInt},
+	}
+
+	for _, tt := range tests {
+		if got := tt.r.Inspect(); got != tt.inspect {
+			t.Errorf("Inspect() wrong. expected=%q, got=%q", tt.inspect, got)
+		}
+		if got := tt.r.Length(); got != tt.length {
+			t.Errorf("%s: Length() wrong. expected=%d, got=%d", tt.inspect, tt.length, got)
+		}
+	}
+
+	r := &Range{Start: 10, End: 13}
+	var values []int64
+	for i, v := range r.All() {
+		if int64(i) != v-10 {
+			t.Errorf("All() yielded index %d with value %d", i, v)
+		}
+		values = append(values, v)
+	}
+	if len(values) != 3 || values[0] != 10 || values[2] != 12 {
+		t.Errorf("All() wrong. got=%v", values)
+	}
+	if v, ok := r.Get(2); !ok || v.Inspect() != "12" {
+		t.Errorf("Get(2) wrong. got=%v (%t)", v, ok)
+	}
+	if _, ok := r.Get(3); ok {
+		t.Errorf("Get(3) should be out of range")
+	}
+}
@@ -0,0 +1,62 @@
+package object
+
+import (
+	"iter"
+	"math"
+	"strconv"
+)
+
+// Range is the sequence of integers written Start..End, which includes End,
+// or Start..<End, which does not. It is empty when End comes before Start.
+// Elements are computed as they are needed, so a range of any length takes
+// the same space.
+type Range struct {
+	Start     int64
+	End       int64
+	Inclusive bool
+}
+
+func (r *Range) Type() ObjectType { return RANGE_OBJ }
+func (r *Range) Inspect() string {
+	operator := "..<"
+	if r.Inclusive {
+		operator = ".."
+	}
+	return strconv.FormatInt(r.Start, 10) + operator + strconv.FormatInt(r.End, 10)
+}
+
+// Length returns the number of integers in the range. Ranges longer than
+// the largest int report that length.
+func (r *Range) Length() int {
+	if r.End < r.Start || r.End == r.Start && !r.Inclusive {
+		return 0
+	}
+	n := uint64(r.End - r.Start)
+	if r.Inclusive {
+		n++
+	}
+	if n == 0 || n > math.MaxInt {
+		return math.MaxInt // n wrapped around: the range covers every int64
+	}
+	return int(n)
+}
+
+// Get returns the integer at index, reporting false when it is out of range.
+func (r *Range) Get(index int) (Object, bool) {
+	if index < 0 || index >= r.Length() {
+		return nil, false
+	}
+	return &Integer{Value: r.Start + int64(index)}, true
+}
+
+// All yields the position and value of each integer in the range in order.
+func (r *Range) All() iter.Seq2[int, int64] {
+	return func(yield func(int, int64) bool) {
+		n := r.Length()
+		for i := 0; i < n; i++ {
+			if !yield(i, r.Start+int64(i)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package object
+
+import "strconv"
+
+// Rune is a single Unicode character, written 'a' in source.
+type Rune struct {
+	Value rune
+}
+
+func (r *Rune) Type() ObjectType { return RUNE_OBJ }
+func (r *Rune) Inspect() string  { return strconv.QuoteRune(r.Value) }
+func (r *Rune) HashKey() HashKey {
+	return HashKey{Type: r.Type(), Value: uint64(r.Value)}
+}
+
+// Integer converts the rune to its Unicode code point.
+func (r *Rune) Integer() *Integer {
+	return &Integer{Value: int64(r.Value)}
+}
+
+// String converts the rune to a one-character string.
+func (r *Rune) String() *String {
+	return &String{Value: string(r.Value)}
+}
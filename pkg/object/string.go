@@ -1 +1,38 @@
-package object
\ No newline at end of file
+package object
+
+import (
+	"hash/fnv"
+	"strconv"
+	"unicode/utf8"
+)
+
+type String struct {
+	Value string
+}
+
+func (s *String) Type() ObjectType { return STRING_OBJ }
+func (s *String) Inspect() string  { return strconv.Quote(s.Value) }
+func (s *String) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write([]byte(s.Value))
+	return HashKey{Type: s.Type(), Value: h.Sum64()}
+}
+
+// Length returns the number of characters (runes) in the string.
+func (s *String) Length() int {
+	return utf8.RuneCountInString(s.Value)
+}
+
+// Substring returns the characters in [start, end). It reports false when the
+// bounds fall outside the string.
+func (s *String) Substring(start, end int) (*String, bool) {
+	runes := []rune(s.Value)
+	if start < 0 || end > len(runes) || start > end {
+		return nil, false
+	}
+	return &String{Value: string(runes[start:end])}, true
+}
+
+func (s *String) Concat(other *String) *String {
+	return &String{Value: s.Value + other.Value}
+}
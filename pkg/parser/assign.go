@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+)
+
+// parseAssignStatement parses an assignment whose first target has been
+// parsed, with the parser on its last token. A comma after it starts a tuple
+// assignment a, b = b, a, which only the plain = operator allows.
+func (p *Parser) parseAssignStatement(target ast.Expression) ast.Statement {
+	targets := []ast.Expression{target}
+	for p.peekTokenIs(lexer.COMMA) {
+		p.nextToken()
+		p.nextToken()
+		targets = append(targets, p.parseExpression(LOWEST))
+	}
+	for _, target := range targets {
+		if !p.checkAssignable(target) {
+			return nil
+		}
+	}
+
+	if len(targets) > 1 && !p.expectPeek(lexer.ASSIGNMENT) {
+		return nil
+	}
+	if len(targets) == 1 {
+		p.nextToken()
+	}
+	stmt := &ast.AssignStatement{Token: p.currentToken, Targets: targets}
+
+	p.nextToken()
+	stmt.Values = []ast.Expression{p.parseExpression(LOWEST)}
+	for p.peekTokenIs(lexer.COMMA) {
+		p.nextToken()
+		p.nextToken()
+		stmt.Values = append(stmt.Values, p.parseExpression(LOWEST))
+	}
+
+	if len(stmt.Targets) != len(stmt.Values) {
+		p.errorf(stmt.Pos(), "assignment mismatch: %d %s but %d %s",
+			len(stmt.Targets), plural(len(stmt.Targets), "variable"),
+			len(stmt.Values), plural(len(stmt.Values), "value"))
+		return nil
+	}
+
+	if p.peekTokenIs(lexer.SEMI_COLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+// parseIncDecStatement parses target++ or target--, with the parser on the
+// last token of target.
+func (p *Parser) parseIncDecStatement(target ast.Expression) ast.Statement {
+	if !p.checkAssignable(target) {
+		return nil
+	}
+	p.nextToken()
+	stmt := &ast.IncDecStatement{Token: p.currentToken, Target: target}
+
+	if p.peekTokenIs(lexer.SEMI_COLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+// checkAssignable reports whether target can be assigned to: a name, an
+// element a[i] or a field x.f. Otherwise it records an error.
+func (p *Parser) checkAssignable(target ast.Expression) bool {
+	switch target.(type) {
+	case *ast.Identifier, *ast.IndexExpression, *ast.SelectorExpression:
+		return true
+	case nil:
+		return false
+	}
+	p.errorf(target.Pos(), "cannot assign to %s", target.String())
+	return false
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
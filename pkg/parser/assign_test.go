@@ -0,0 +1,121 @@
+package parser_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/parser"
+)
+
+func TestAssignStatements(t *testing.T) {
+	tests := []struct {
+		input            string
+		expected         string
+		expectedOperator lexer.TokenType
+		targets          int
+	}{
+		{"x = 5", "x = 5;", lexer.ASSIGNMENT, 1},
+		{"x = y + 1;", "x = (y + 1);", lexer.ASSIGNMENT, 1},
+		{"x += 2", "x += 2;", lexer.PLUS_EQUALS, 1},
+		{"x -= 2", "x -= 2;", lexer.MINUS_EQUALS, 1},
+		{"x *= 2", "x *= 2;", lexer.STAR_EQUALS, 1},
+		{"x /= 2", "x /= 2;", lexer.SLASH_EQUALS, 1},
+		{"a[i] -= 1", "(a[i]) -= 1;", lexer.MINUS_EQUALS, 1},
+		{"p.x *= 2", "p.x *= 2;", lexer.STAR_EQUALS, 1},
+		{"a.b.c = 1", "a.b.c = 1;", lexer.ASSIGNMENT, 1},
+		{"a, b = b, a", "a, b = b, a;", lexer.ASSIGNMENT, 2},
+		{"a[i], a[j] = a[j], a[i]", "(a[i]), (a[j]) = (a[j]), (a[i]);", lexer.ASSIGNMENT, 2},
+		{"x, y, z = 1, 2, 3", "x, y, z = 1, 2, 3;", lexer.ASSIGNMENT, 3},
+	}
+
+	for _, tt := range tests {
+		stmt, ok := parseSingleStatement(t, tt.input).(*ast.AssignStatement)
+		if !ok {
+			t.Fatalf("%q: statement is not *ast.AssignStatement", tt.input)
+		}
+		if got := stmt.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+		if stmt.Token.Type != tt.expectedOperator {
+			t.Errorf("%q: operator wrong. expected=%s, got=%s", tt.input, tt.expectedOperator, stmt.Token.Type)
+		}
+		if len(stmt.Targets) != tt.targets || len(stmt.Values) != tt.targets {
+			t.Errorf("%q: expected %d targets and values, got %d and %d",
+				tt.input, tt.targets, len(stmt.Targets), len(stmt.Values))
+		}
+	}
+}
+
+func TestIncDecStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x++", "x++;"},
+		{"x--;", "x--;"},
+		{"a[0]++", "(a[0])++;"},
+		{"p.count--", "p.count--;"},
+	}
+
+	for _, tt := range tests {
+		stmt, ok := parseSingleStatement(t, tt.input).(*ast.IncDecStatement)
+		if !ok {
+			t.Fatalf("%q: statement is not *ast.IncDecStatement", tt.input)
+		}
+		if got := stmt.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+
+	stmt := parseSingleStatement(t, "for i := 0; i < n; i++ { }").(*ast.ForStatement)
+	if post, ok := stmt.Post.(*ast.IncDecStatement); !ok || post.String() != "i++;" {
+		t.Errorf("for post is not i++. got=%T (%v)", stmt.Post, stmt.Post)
+	}
+}
+
+func TestSelectorExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"p.x", "p.x"},
+		{"a.b.c", "a.b.c"},
+		{"a * p.x + 1", "((a * p.x) + 1)"},
+		{"p.items[0].name", "(p.items[0]).name"},
+		{"-p.x", "(-p.x)"},
+		{"p.f(1)", "p.f(1)"},
+	}
+
+	for _, tt := range tests {
+		if got := parseSingleStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestAssignErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"f() = 5", "1:1: cannot assign to f()"},
+		{"1 += 2", "1:1: cannot assign to 1"},
+		{"a, f() = 1, 2", "1:4: cannot assign to f()"},
+		{"(a + b)++", "1:2: cannot assign to (a + b)"},
+		{"a, b += 1, 2", "1:6: expected next token to be ASSIGNMENT, got PLUS_EQUALS instead"},
+		{"a, b = 1", "1:1: assignment mismatch: 2 variables but 1 value"},
+		{"a = 1, 2", "1:1: assignment mismatch: 1 variable but 2 values"},
+		{"p.(x) = 2", "1:3: expected next token to be IDENTIFIER, got OPEN_PARENTHESES instead"},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 || errors[0] != tt.expectedError {
+			t.Errorf("%q: expected error %q, got %q", tt.input, tt.expectedError, errors)
+		}
+	}
+}
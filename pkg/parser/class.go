@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+)
+
+// parseClassStatement parses class Name extends Base { ... }. The body holds
+// methods, name(params) { body }, which may be separated by semicolons.
+func (p *Parser) parseClassStatement() ast.Statement {
+	stmt := &ast.ClassStatement{Token: p.currentToken, Methods: []*ast.ClassMethod{}}
+
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	if p.peekTokenIs(lexer.EXTENDS) {
+		p.nextToken()
+		if !p.expectPeek(lexer.IDENTIFIER) {
+			return nil
+		}
+		stmt.SuperClass = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+	}
+
+	if !p.expectPeek(lexer.OPEN_CURLY) {
+		return nil
+	}
+
+	names := make(map[string]bool)
+	for {
+		for p.peekTokenIs(lexer.SEMI_COLON) {
+			p.nextToken()
+		}
+		if p.peekTokenIs(lexer.CLOSE_CURLY) {
+			p.nextToken()
+			break
+		}
+
+		method := p.parseClassMethod()
+		if method == nil {
+			return nil
+		}
+		if names[method.Name.Value] {
+			p.errorf(method.Pos(), "duplicate method %s", method.Name.Value)
+			return nil
+		}
+		names[method.Name.Value] = true
+		stmt.Methods = append(stmt.Methods, method)
+	}
+
+	if p.peekTokenIs(lexer.SEMI_COLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+// parseClassMethod parses one method of a class body, starting from the
+// token before its name.
+func (p *Parser) parseClassMethod() *ast.ClassMethod {
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil
+	}
+	method := &ast.ClassMethod{Name: &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}}
+
+	lit := &ast.FunctionLiteral{Token: p.currentToken}
+	if !p.expectPeek(lexer.OPEN_PARENTHESES) {
+		return nil
+	}
+	lit.Parameters, lit.ParameterTypes = p.parseFunctionParameters()
+	if lit.Parameters == nil || !p.checkParameterTypes(lit) || !p.parseFunctionBody(lit) {
+		return nil
+	}
+	if lit.Result != nil && method.Name.Value == "constructor" {
+		p.errorf(lit.Result.Pos(), "constructor cannot have a result type")
+		return nil
+	}
+	method.Function = lit
+	return method
+}
+
+// parseNewExpression parses new Class(args). The parentheses may be left
+// out when there are no arguments.
+func (p *Parser) parseNewExpression() ast.Expression {
+	exp := &ast.NewExpression{Token: p.currentToken, Arguments: []ast.Expression{}}
+
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil
+	}
+	exp.Class = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	if p.peekTokenIs(lexer.OPEN_PARENTHESES) {
+		p.nextToken()
+		if exp.Arguments = p.parseExpressionList(lexer.CLOSE_PARENTHESES); exp.Arguments == nil {
+			return nil
+		}
+	}
+	return exp
+}
+
+func (p *Parser) parseThisExpression() ast.Expression {
+	return &ast.ThisExpression{Token: p.currentToken}
+}
+
+// parseSuperExpression parses super, which must be called or selected from.
+func (p *Parser) parseSuperExpression() ast.Expression {
+	if !p.peekTokenIs(lexer.OPEN_PARENTHESES) && !p.peekTokenIs(lexer.DOT) {
+		p.peekError(lexer.OPEN_PARENTHESES, lexer.DOT)
+		return nil
+	}
+	return &ast.SuperExpression{Token: p.currentToken}
+}
@@ -0,0 +1,115 @@
+package parser_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/parser"
+)
+
+func TestClassStatements(t *testing.T) {
+	tests := []struct {
+		input           string
+		expected        string
+		expectedSuper   string
+		expectedMethods []string
+	}{
+		{"class Empty {}", "class Empty {}", "", []string{}},
+		{
+			"class Animal {\n\tconstructor(name) { this.name = name }\n\tspeak() { this.name }\n}",
+			"class Animal { constructor(name) { this.name = name; } speak() { this.name } }",
+			"",
+			[]string{"constructor", "speak"},
+		},
+		{
+			"class Dog extends Animal { speak() string { super.speak() + \"!\" } }",
+			"class Dog extends Animal { speak() string { (super.speak() + \"!\") } }",
+			"Animal",
+			[]string{"speak"},
+		},
+		{
+			"class Counter { constructor() { super() }; add(n int) { this.n += n } }",
+			"class Counter { constructor() { super() } add(n int) { this.n += n; } }",
+			"",
+			[]string{"constructor", "add"},
+		},
+	}
+
+	for _, tt := range tests {
+		stmt, ok := parseSingleStatement(t, tt.input).(*ast.ClassStatement)
+		if !ok {
+			t.Fatalf("%q: statement is not *ast.ClassStatement", tt.input)
+		}
+		if got := stmt.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+		if tt.expectedSuper == "" && stmt.SuperClass != nil {
+			t.Errorf("%q: unexpected superclass %s", tt.input, stmt.SuperClass)
+		}
+		if tt.expectedSuper != "" {
+			testIdentifier(t, stmt.SuperClass, tt.expectedSuper)
+		}
+		if len(stmt.Methods) != len(tt.expectedMethods) {
+			t.Fatalf("%q: expected %d methods, got %d", tt.input, len(tt.expectedMethods), len(stmt.Methods))
+		}
+		for i, method := range stmt.Methods {
+			testIdentifier(t, method.Name, tt.expectedMethods[i])
+		}
+	}
+}
+
+func TestClassExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"new Dog(\"Rex\", 3)", "new Dog(\"Rex\", 3)"},
+		{"new Dog", "new Dog()"},
+		{"new Dog().speak()", "new Dog().speak()"},
+		{"d instanceof Dog", "(d instanceof Dog)"},
+		{"d instanceof Dog == true", "((d instanceof Dog) == true)"},
+		{"typeof x", "(typeof x)"},
+		{"typeof x == \"int\"", "((typeof x) == \"int\")"},
+		{"this.x = 1", "this.x = 1;"},
+		{"super.f(this)", "super.f(this)"},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		program := p.ParseProgram()
+		CheckParserErrors(t, p)
+
+		if got := program.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestClassErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"class { }", "1:7: expected next token to be IDENTIFIER, got OPEN_CURLY instead"},
+		{"class A extends { }", "1:17: expected next token to be IDENTIFIER, got OPEN_CURLY instead"},
+		{"class A { f() {} f() {} }", "1:18: duplicate method f"},
+		{"class A { f() {} g() {} x }", "1:27: expected next token to be OPEN_PARENTHESES, got CLOSE_CURLY instead"},
+		{"class A { f() {} g() {}", "1:24: expected next token to be IDENTIFIER, got EOF instead"},
+		{"class A { f() {} g }", "1:20: expected next token to be OPEN_PARENTHESES, got CLOSE_CURLY instead"},
+		{"class A { constructor() int {} }", "1:25: constructor cannot have a result type"},
+		{"class A { fn f() {} }", "1:11: expected next token to be IDENTIFIER, got FUNCTION instead"},
+		{"new 1", "1:5: expected next token to be IDENTIFIER, got INT instead"},
+		{"let s = super", "1:14: expected next token to be OPEN_PARENTHESES or DOT, got SEMI_COLON instead"},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 || errors[0] != tt.expectedError {
+			t.Errorf("%q: expected error %q, got %q", tt.input, tt.expectedError, errors)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+)
+
+// parseArrayLiteral parses [a, b, c] and the typed form []T{a, b, c}. An
+// empty [] not followed by a type is an empty untyped array.
+func (p *Parser) parseArrayLiteral() ast.Expression {
+	array := &ast.ArrayLiteral{Token: p.currentToken}
+
+	if p.peekTokenIs(lexer.CLOSE_BRACKET) {
+		p.nextToken()
+		if !p.peekTokenIs(lexer.IDENTIFIER) && !p.peekTokenIs(lexer.OPEN_BRACKET) {
+			array.Elements = []ast.Expression{}
+			return array
+		}
+
+		p.nextToken()
+		array.Type = &ast.ArrayType{Token: array.Token, Element: p.parseType()}
+		if array.Type.Element == nil || !p.expectPeek(lexer.OPEN_CURLY) {
+			return nil
+		}
+		array.Elements = p.parseExpressionList(lexer.CLOSE_CURLY)
+	} else {
+		array.Elements = p.parseExpressionList(lexer.CLOSE_BRACKET)
+	}
+
+	if array.Elements == nil {
+		return nil
+	}
+	return array
+}
+
+// parseType parses the type starting at the current token: a type name such
+// as int, boolean or Point, []T, or a struct type.
+func (p *Parser) parseType() ast.Expression {
+	switch {
+	case p.currentTokenIs(lexer.IDENTIFIER):
+		return &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+	case p.currentTokenIs(lexer.STRUCT_TYPE):
+		return p.parseStructTypeExpression()
+	case p.currentTokenIs(lexer.OPEN_BRACKET) && p.peekTokenIs(lexer.CLOSE_BRACKET):
+		arrayType := &ast.ArrayType{Token: p.currentToken}
+		p.nextToken()
+		p.nextToken()
+		if arrayType.Element = p.parseType(); arrayType.Element == nil {
+			return nil
+		}
+		return arrayType
+	default:
+		p.errorf(p.currentToken.Start, "expected type, got %s", p.currentToken.Type)
+		return nil
+	}
+}
+
+// parseHashLiteral parses {key: value, ...}, allowing a trailing comma.
+func (p *Parser) parseHashLiteral() ast.Expression {
+	hash := &ast.HashLiteral{Token: p.currentToken, Pairs: []ast.HashLiteralPair{}}
+
+	p.exprLev++
+	defer func() { p.exprLev-- }()
+
+	for !p.peekTokenIs(lexer.CLOSE_CURLY) {
+		p.nextToken()
+		key := p.parseExpression(LOWEST)
+
+		if !p.expectPeek(lexer.COLON) {
+			return nil
+		}
+
+		p.nextToken()
+		value := p.parseExpression(LOWEST)
+		hash.Pairs = append(hash.Pairs, ast.HashLiteralPair{Key: key, Value: value})
+
+		if p.peekTokenIs(lexer.COMMA) {
+			p.nextToken()
+		} else if !p.peekTokenIs(lexer.CLOSE_CURLY) {
+			p.peekError(lexer.COMMA, lexer.CLOSE_CURLY)
+			return nil
+		}
+	}
+
+	if !p.expectPeek(lexer.CLOSE_CURLY) {
+		return nil
+	}
+	return hash
+}
+
+// parseIndexExpression parses left[index] and the slice forms left[low:high]
+// and left[low:high:max]. In a slice, low and high may be omitted; max may
+// not, nor may high when max is present.
+func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
+	token := p.currentToken
+
+	p.exprLev++
+	defer func() { p.exprLev-- }()
+
+	var index ast.Expression
+	if !p.peekTokenIs(lexer.COLON) {
+		p.nextToken()
+		index = p.parseExpression(LOWEST)
+	}
+	if !p.peekTokenIs(lexer.COLON) {
+		if !p.peekTokenIs(lexer.CLOSE_BRACKET) {
+			p.peekError(lexer.CLOSE_BRACKET, lexer.COLON)
+			return nil
+		}
+		p.nextToken()
+		return &ast.IndexExpression{Token: token, Left: left, Index: index}
+	}
+
+	slice := &ast.SliceExpression{Token: token, Left: left, Low: index}
+	p.nextToken()
+	if !p.peekTokenIs(lexer.COLON) && !p.peekTokenIs(lexer.CLOSE_BRACKET) {
+		p.nextToken()
+		slice.High = p.parseExpression(LOWEST)
+	}
+
+	if p.peekTokenIs(lexer.COLON) {
+		p.nextToken()
+		if slice.High == nil {
+			p.errorf(p.currentToken.Start, "middle index required in 3-index slice")
+			return nil
+		}
+		if p.peekTokenIs(lexer.CLOSE_BRACKET) {
+			p.errorf(p.peekToken.Start, "final index required in 3-index slice")
+			return nil
+		}
+		p.nextToken()
+		slice.Max = p.parseExpression(LOWEST)
+	}
+
+	if !p.expectPeek(lexer.CLOSE_BRACKET) {
+		return nil
+	}
+	return slice
+}
+
+// parseRangeExpression parses start..end and start..<end.
+func (p *Parser) parseRangeExpression(start ast.Expression) ast.Expression {
+	exp := &ast.RangeExpression{Token: p.currentToken, Start: start, Operator: p.currentToken.Literal}
+
+	precedence := p.currentPrecedence()
+	p.nextToken()
+	exp.End = p.parseExpression(precedence)
+	return exp
+}
@@ -0,0 +1,149 @@
+package parser_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/parser"
+)
+
+func TestArrayLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		length   int
+	}{
+		{"[1, 2 * 2, 3 + 3]", "[1, (2 * 2), (3 + 3)]", 3},
+		{"[]", "[]", 0},
+		{"[1, 2,]", "[1, 2]", 2},
+		{"[\n\t1,\n\t2,\n]", "[1, 2]", 2},
+		{"[]int{1, 2, 3}", "[]int{1, 2, 3}", 3},
+		{"[]string{}", "[]string{}", 0},
+		{"[][]int{[]int{1}, []int{2, 3}}", "[][]int{[]int{1}, []int{2, 3}}", 2},
+		{"[]float{\n\t1.5,\n\t2,\n}", "[]float{1.5, 2}", 2},
+	}
+
+	for _, tt := range tests {
+		stmt := parseSingleStatement(t, tt.input).(*ast.ExpressionStatement)
+		array, ok := stmt.Expression.(*ast.ArrayLiteral)
+		if !ok {
+			t.Fatalf("%q: exp not ast.ArrayLiteral. got=%T", tt.input, stmt.Expression)
+		}
+		if len(array.Elements) != tt.length {
+			t.Errorf("%q: len(array.Elements) not %d. got=%d", tt.input, tt.length, len(array.Elements))
+		}
+		if got := array.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestTypedArrayLiteralType(t *testing.T) {
+	stmt := parseSingleStatement(t, "[][]string{}").(*ast.ExpressionStatement)
+	array := stmt.Expression.(*ast.ArrayLiteral)
+
+	if array.Type == nil {
+		t.Fatalf("array.Type is nil")
+	}
+	inner, ok := array.Type.Element.(*ast.ArrayType)
+	if !ok {
+		t.Fatalf("element type not ast.ArrayType. got=%T", array.Type.Element)
+	}
+	testIdentifier(t, inner.Element, "string")
+}
+
+func TestHashLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"one": 1, "two": 2, "three": 3}`, `{"one": 1, "two": 2, "three": 3}`},
+		{"{}", "{}"},
+		{`{"one": 0 + 1, "two": 10 - 8}`, `{"one": (0 + 1), "two": (10 - 8)}`},
+		{"{\n\t\"name\": \"World\",\n\t\"age\": 2024,\n}", `{"name": "World", "age": 2024}`},
+		{"{1: [1], true: {}}", "{1: [1], true: {}}"},
+	}
+
+	for _, tt := range tests {
+		stmt := parseSingleStatement(t, tt.input).(*ast.ExpressionStatement)
+		hash, ok := stmt.Expression.(*ast.HashLiteral)
+		if !ok {
+			t.Fatalf("%q: exp is not ast.HashLiteral. got=%T", tt.input, stmt.Expression)
+		}
+		if got := hash.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"myArray[1 + 1]", "(myArray[(1 + 1)])"},
+		{"a * [1, 2, 3, 4][b * c] * d", "((a * ([1, 2, 3, 4][(b * c)])) * d)"},
+		{"add(a * b[2], b[1], 2 * [1, 2][1])", "add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))"},
+		{`h["a"]["b"]`, `((h["a"])["b"])`},
+		{"fns[0](1)", "(fns[0])(1)"},
+		{"a[1:2]", "(a[1:2])"},
+		{"a[:n - 1]", "(a[:(n - 1)])"},
+		{"a[i:]", "(a[i:])"},
+		{"a[:]", "(a[:])"},
+		{"a[0:2:cap]", "(a[0:2:cap])"},
+		{"a[1:][0]", "((a[1:])[0])"},
+		{"1..10", "(1..10)"},
+		{"0..<n", "(0..<n)"},
+		{"a + 1..b * 2", "((a + 1)..(b * 2))"},
+		{"1..n == r", "((1..n) == r)"},
+		{"(1..5)[1:]", "((1..5)[1:])"},
+	}
+
+	for _, tt := range tests {
+		if got := parseSingleStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestIndexAssignment(t *testing.T) {
+	stmt, ok := parseSingleStatement(t, `h["k"][0] = v + 1`).(*ast.AssignStatement)
+	if !ok {
+		t.Fatalf("statement is not ast.AssignStatement")
+	}
+	if got := stmt.Targets[0].String(); got != `((h["k"])[0])` {
+		t.Errorf("stmt.Targets[0] wrong. got=%q", got)
+	}
+	if got := stmt.Values[0].String(); got != "(v + 1)" {
+		t.Errorf("stmt.Values[0] wrong. got=%q", got)
+	}
+}
+
+func TestCollectionErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"[1, 2", "1:6: expected next token to be COMMA or CLOSE_BRACKET, got SEMI_COLON instead"},
+		{"[]int(1)", "1:6: expected next token to be OPEN_CURLY, got OPEN_PARENTHESES instead"},
+		{"[][]{1}", "1:5: expected type, got OPEN_CURLY"},
+		{`{"a" 1}`, "1:6: expected next token to be COLON, got INT instead"},
+		{`{"a": 1 "b": 2}`, "1:9: expected next token to be COMMA or CLOSE_CURLY, got STRING instead"},
+		{"a[1", "1:4: expected next token to be CLOSE_BRACKET or COLON, got SEMI_COLON instead"},
+		{"a[1:2", "1:6: expected next token to be CLOSE_BRACKET, got SEMI_COLON instead"},
+		{"a[::2]", "1:4: middle index required in 3-index slice"},
+		{"a[1:2:]", "1:7: final index required in 3-index slice"},
+		{"f() = 5", "1:1: cannot assign to f()"},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 || errors[0] != tt.expectedError {
+			t.Errorf("%q: expected error %q, got %q", tt.input, tt.expectedError, errors)
+		}
+	}
+}
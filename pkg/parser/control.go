@@ -0,0 +1,241 @@
+package parser
+
+import (
+	"slices"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+)
+
+func (p *Parser) parseBoolean() ast.Expression {
+	return &ast.Boolean{Token: p.currentToken, Value: p.currentTokenIs(lexer.TRUE)}
+}
+
+func (p *Parser) parseNullLiteral() ast.Expression {
+	return &ast.NullLiteral{Token: p.currentToken}
+}
+
+// parseIfExpression parses if cond { ... }, optionally followed by
+// else { ... } or else if ..., which nests another IfExpression.
+func (p *Parser) parseIfExpression() ast.Expression {
+	expression := &ast.IfExpression{Token: p.currentToken}
+
+	exprLev := p.exprLev
+	p.exprLev = -1
+	defer func() { p.exprLev = exprLev }()
+
+	p.nextToken()
+	expression.Condition = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(lexer.OPEN_CURLY) {
+		return nil
+	}
+	expression.Consequence = p.parseBlockStatement()
+
+	if !p.peekTokenIs(lexer.ELSE) {
+		return expression
+	}
+	p.nextToken()
+
+	if p.peekTokenIs(lexer.IF) {
+		p.nextToken()
+		nested, ok := p.parseIfExpression().(*ast.IfExpression)
+		if !ok {
+			return nil
+		}
+		expression.Alternative = &ast.BlockStatement{
+			Token:      nested.Token,
+			Statements: []ast.Statement{&ast.ExpressionStatement{Token: nested.Token, Expression: nested}},
+		}
+		return expression
+	}
+
+	if !p.expectPeek(lexer.OPEN_CURLY) {
+		return nil
+	}
+	expression.Alternative = p.parseBlockStatement()
+	return expression
+}
+
+// parseTernaryExpression parses cond ? a : b. Both branches are parsed at the
+// lowest precedence, so a ? b : c ? d : e groups as a ? b : (c ? d : e).
+func (p *Parser) parseTernaryExpression(condition ast.Expression) ast.Expression {
+	exp := &ast.TernaryExpression{Token: p.currentToken, Condition: condition}
+
+	p.nextToken()
+	exp.Consequence = p.parseExpression(LOWEST)
+	if !p.expectPeek(lexer.COLON) {
+		return nil
+	}
+
+	p.nextToken()
+	exp.Alternative = p.parseExpression(LOWEST)
+	if exp.Consequence == nil || exp.Alternative == nil {
+		return nil
+	}
+	return exp
+}
+
+// parseLoopBody parses the block of a loop labeled label, so that break and
+// continue statements inside it can be checked.
+func (p *Parser) parseLoopBody(label string) *ast.BlockStatement {
+	if !p.expectPeek(lexer.OPEN_CURLY) {
+		return nil
+	}
+	p.loops = append(p.loops, label)
+	body := p.parseBlockStatement()
+	p.loops = p.loops[:len(p.loops)-1]
+	return body
+}
+
+func (p *Parser) parseWhileStatement(label string) ast.Statement {
+	stmt := &ast.WhileStatement{Token: p.currentToken}
+
+	exprLev := p.exprLev
+	p.exprLev = -1
+	defer func() { p.exprLev = exprLev }()
+
+	p.nextToken()
+	stmt.Condition = p.parseExpression(LOWEST)
+
+	if stmt.Body = p.parseLoopBody(label); stmt.Body == nil {
+		return nil
+	}
+	return stmt
+}
+
+// parseForStatement parses the three forms of for loop:
+//
+//	for { ... }
+//	for cond { ... }
+//	for init; cond; post { ... }
+func (p *Parser) parseForStatement(label string) ast.Statement {
+	stmt := &ast.ForStatement{Token: p.currentToken}
+
+	exprLev := p.exprLev
+	p.exprLev = -1
+	defer func() { p.exprLev = exprLev }()
+
+	if !p.peekTokenIs(lexer.OPEN_CURLY) {
+		p.nextToken()
+
+		// A statement that is not followed by a semicolon is the condition
+		// of a for cond { ... } loop.
+		if !p.currentTokenIs(lexer.SEMI_COLON) {
+			init := p.parseSimpleStatement()
+			if exp, ok := init.(*ast.ExpressionStatement); ok && p.peekTokenIs(lexer.OPEN_CURLY) {
+				stmt.Condition = exp.Expression
+				if stmt.Body = p.parseLoopBody(label); stmt.Body == nil {
+					return nil
+				}
+				return stmt
+			}
+			if !p.currentTokenIs(lexer.SEMI_COLON) {
+				p.peekError(lexer.SEMI_COLON)
+				return nil
+			}
+			stmt.Init = init
+		}
+
+		p.nextToken()
+		if !p.currentTokenIs(lexer.SEMI_COLON) {
+			stmt.Condition = p.parseExpression(LOWEST)
+			if !p.expectPeek(lexer.SEMI_COLON) {
+				return nil
+			}
+		}
+
+		if !p.peekTokenIs(lexer.OPEN_CURLY) {
+			p.nextToken()
+			stmt.Post = p.parseSimpleStatement()
+		}
+	}
+
+	if stmt.Body = p.parseLoopBody(label); stmt.Body == nil {
+		return nil
+	}
+	return stmt
+}
+
+// parseForeachStatement parses foreach value in collection { ... } and
+// foreach index, value in collection { ... }.
+func (p *Parser) parseForeachStatement(label string) ast.Statement {
+	stmt := &ast.ForeachStatement{Token: p.currentToken}
+
+	exprLev := p.exprLev
+	p.exprLev = -1
+	defer func() { p.exprLev = exprLev }()
+
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil
+	}
+	stmt.Value = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	if p.peekTokenIs(lexer.COMMA) {
+		p.nextToken()
+		if !p.expectPeek(lexer.IDENTIFIER) {
+			return nil
+		}
+		stmt.Index = stmt.Value
+		stmt.Value = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+	}
+
+	if !p.expectPeek(lexer.IN) {
+		return nil
+	}
+	p.nextToken()
+	stmt.Iterable = p.parseExpression(LOWEST)
+
+	if stmt.Body = p.parseLoopBody(label); stmt.Body == nil {
+		return nil
+	}
+	return stmt
+}
+
+// parseBranchStatement parses break and continue, checking that they are
+// inside a loop and that a label, if given, names an enclosing loop.
+func (p *Parser) parseBranchStatement() ast.Statement {
+	stmt := &ast.BranchStatement{Token: p.currentToken}
+
+	if p.peekTokenIs(lexer.IDENTIFIER) {
+		p.nextToken()
+		stmt.Label = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+	}
+
+	switch {
+	case len(p.loops) == 0:
+		p.errorf(stmt.Token.Start, "%s is not in a loop", stmt.Token.Literal)
+	case stmt.Label != nil && !slices.Contains(p.loops, stmt.Label.Value):
+		p.errorf(stmt.Label.Pos(), "%s label not defined: %s", stmt.Token.Literal, stmt.Label.Value)
+	}
+
+	if p.peekTokenIs(lexer.SEMI_COLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+// parseLabeledStatement parses label: followed by the loop it labels.
+func (p *Parser) parseLabeledStatement() ast.Statement {
+	stmt := &ast.LabeledStatement{Token: p.currentToken}
+	stmt.Label = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+	p.nextToken() // the colon
+	p.nextToken()
+
+	switch p.currentToken.Type {
+	case lexer.WHILE:
+		stmt.Statement = p.parseWhileStatement(stmt.Label.Value)
+	case lexer.FOR:
+		stmt.Statement = p.parseForStatement(stmt.Label.Value)
+	case lexer.FOREACH:
+		stmt.Statement = p.parseForeachStatement(stmt.Label.Value)
+	default:
+		p.errorf(p.currentToken.Start, "label %s must be followed by a loop, got %s", stmt.Label.Value, p.currentToken.Type)
+		return nil
+	}
+
+	if stmt.Statement == nil {
+		return nil
+	}
+	return stmt
+}
@@ -0,0 +1,163 @@
+package parser_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/parser"
+)
+
+func TestBooleanAndNullExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"true", "true"},
+		{"false", "false"},
+		{"3 > 5 == false", "((3 > 5) == false)"},
+		{"!true", "(!true)"},
+		{"null", "null"},
+	}
+
+	for _, tt := range tests {
+		stmt := parseSingleStatement(t, tt.input).(*ast.ExpressionStatement)
+		if got := stmt.String(); got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+
+	stmt := parseSingleStatement(t, "true").(*ast.ExpressionStatement)
+	if b, ok := stmt.Expression.(*ast.Boolean); !ok || !b.Value {
+		t.Errorf("expected *ast.Boolean true, got %T (%+v)", stmt.Expression, stmt.Expression)
+	}
+}
+
+func TestIfExpression(t *testing.T) {
+	stmt := parseSingleStatement(t, "if x < y { x }").(*ast.ExpressionStatement)
+
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not ast.IfExpression. got=%T", stmt.Expression)
+	}
+	if got := exp.Condition.String(); got != "(x < y)" {
+		t.Errorf("condition wrong. got=%q", got)
+	}
+	if len(exp.Consequence.Statements) != 1 {
+		t.Fatalf("consequence is not 1 statement. got=%d", len(exp.Consequence.Statements))
+	}
+	if exp.Alternative != nil {
+		t.Errorf("exp.Alternative was not nil. got=%+v", exp.Alternative)
+	}
+}
+
+func TestIfElseChains(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"if (x < y) { x } else { y }", "if (x < y) { x } else { y }"},
+		{"if x { 1 } else if y { 2 } else { 3 }", "if x { 1 } else { if y { 2 } else { 3 } }"},
+		{"if a {\n\t1\n} else if b {\n\t2\n}\n", "if a { 1 } else { if b { 2 } }"},
+		{"let v = if a { 1 } else { 2 }", "let v = if a { 1 } else { 2 };"},
+	}
+
+	for _, tt := range tests {
+		if got := parseSingleStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestTernaryExpression(t *testing.T) {
+	stmt := parseSingleStatement(t, "x < 0 ? -x : x").(*ast.ExpressionStatement)
+
+	exp, ok := stmt.Expression.(*ast.TernaryExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not *ast.TernaryExpression. got=%T", stmt.Expression)
+	}
+	if got := exp.Condition.String(); got != "(x < 0)" {
+		t.Errorf("exp.Condition wrong. got=%q", got)
+	}
+	if got := exp.Consequence.String(); got != "(-x)" {
+		t.Errorf("exp.Consequence wrong. got=%q", got)
+	}
+	testIdentifier(t, exp.Alternative, "x")
+}
+
+func TestLoopStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"while x < 10 { x }", "while (x < 10) { x }"},
+		{"for { x }", "for { x }"},
+		{"for x < 10 { x }", "for (x < 10) { x }"},
+		{"for let i = 0; i < 10; let i = i + 1 { i }", "for let i = 0; (i < 10); let i = (i + 1) { i }"},
+		{"for ; ; { break }", "for { break; }"},
+		{"for let i = 0; ; { }", "for let i = 0; ; { }"},
+		{"foreach x in xs { x }", "foreach x in xs { x }"},
+		{"foreach k, v in items() {\n\tk\n}", "foreach k, v in items() { k }"},
+		{"outer: for {\n\tfor { break outer }\n}", "outer: for { for { break outer; } }"},
+		{"loop:\nwhile true { continue loop }", "loop: while true { continue loop; }"},
+	}
+
+	for _, tt := range tests {
+		if got := parseSingleStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestForStatementParts(t *testing.T) {
+	stmt, ok := parseSingleStatement(t, "for let i = 0; i < 3; i { }").(*ast.ForStatement)
+	if !ok {
+		t.Fatalf("statement is not ast.ForStatement")
+	}
+	if _, ok := stmt.Init.(*ast.LetStatement); !ok {
+		t.Errorf("stmt.Init is not ast.LetStatement. got=%T", stmt.Init)
+	}
+	if stmt.Condition == nil || stmt.Condition.String() != "(i < 3)" {
+		t.Errorf("stmt.Condition wrong. got=%v", stmt.Condition)
+	}
+	if _, ok := stmt.Post.(*ast.ExpressionStatement); !ok {
+		t.Errorf("stmt.Post is not ast.ExpressionStatement. got=%T", stmt.Post)
+	}
+
+	foreach, ok := parseSingleStatement(t, "foreach v in xs {}").(*ast.ForeachStatement)
+	if !ok {
+		t.Fatalf("statement is not ast.ForeachStatement")
+	}
+	if foreach.Index != nil || foreach.Value.Value != "v" {
+		t.Errorf("foreach variables wrong. index=%v value=%v", foreach.Index, foreach.Value)
+	}
+}
+
+func TestControlFlowErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"break", "1:1: break is not in a loop"},
+		{"while x { fn() { continue } }", "1:18: continue is not in a loop"},
+		{"outer: for { break inner }", "1:20: break label not defined: inner"},
+		{"a: for {}\nfor { continue a }", "2:16: continue label not defined: a"},
+		{"lbl: let x = 1", "1:6: label lbl must be followed by a loop, got LET"},
+		{"if x { 1 } else 2", "1:17: expected next token to be OPEN_CURLY, got INT instead"},
+		{"foreach x of xs {}", "1:11: expected next token to be IN, got IDENTIFIER instead"},
+		{"for let i = 0 i < 3 {}", "1:15: expected next token to be SEMI_COLON, got IDENTIFIER instead"},
+		{"a ? b", "1:6: expected next token to be COLON, got SEMI_COLON instead"},
+		{"a ? b c", "1:7: expected next token to be COLON, got IDENTIFIER instead"},
+		{"while x\n{ }", "1:8: expected next token to be OPEN_CURLY, got SEMI_COLON instead"},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 || errors[0] != tt.expectedError {
+			t.Errorf("%q: expected error %q, got %q", tt.input, tt.expectedError, errors)
+		}
+	}
+}
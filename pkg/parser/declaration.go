@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+)
+
+// parseVarStatement parses var name type = value, where either the type or
+// the value may be left out, and the grouped form var ( ... ).
+func (p *Parser) parseVarStatement() ast.Statement {
+	stmt := &ast.VarStatement{Token: p.currentToken}
+
+	stmt.Specs, stmt.Grouped = p.parseValueSpecs(false)
+	if stmt.Specs == nil {
+		return nil
+	}
+	return stmt
+}
+
+// parseConstStatement parses const name type = value, where the type may be
+// left out, and the grouped form const ( ... ).
+func (p *Parser) parseConstStatement() ast.Statement {
+	stmt := &ast.ConstStatement{Token: p.currentToken}
+
+	stmt.Specs, stmt.Grouped = p.parseValueSpecs(true)
+	if stmt.Specs == nil {
+		return nil
+	}
+	return stmt
+}
+
+// parseValueSpecs parses what follows var or const: one spec, or a group of
+// specs in parentheses separated by semicolons or newlines. It reports
+// whether the specs were grouped.
+func (p *Parser) parseValueSpecs(constant bool) ([]*ast.ValueSpec, bool) {
+	if !p.peekTokenIs(lexer.OPEN_PARENTHESES) {
+		spec := p.parseValueSpec(constant)
+		if spec == nil {
+			return nil, false
+		}
+		if p.peekTokenIs(lexer.SEMI_COLON) {
+			p.nextToken()
+		}
+		return []*ast.ValueSpec{spec}, false
+	}
+
+	p.nextToken()
+	specs := []*ast.ValueSpec{}
+	for {
+		for p.peekTokenIs(lexer.SEMI_COLON) {
+			p.nextToken()
+		}
+		if p.peekTokenIs(lexer.CLOSE_PARENTHESES) {
+			p.nextToken()
+			break
+		}
+
+		spec := p.parseValueSpec(constant)
+		if spec == nil {
+			return nil, true
+		}
+		specs = append(specs, spec)
+
+		if !p.peekTokenIs(lexer.SEMI_COLON) && !p.peekTokenIs(lexer.CLOSE_PARENTHESES) {
+			p.peekError(lexer.SEMI_COLON, lexer.CLOSE_PARENTHESES)
+			return nil, true
+		}
+	}
+
+	if p.peekTokenIs(lexer.SEMI_COLON) {
+		p.nextToken()
+	}
+	return specs, true
+}
+
+// parseValueSpec parses name type = value starting from the token before
+// the name. A constant must have a value.
+func (p *Parser) parseValueSpec(constant bool) *ast.ValueSpec {
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil
+	}
+	spec := &ast.ValueSpec{Name: &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}}
+
+	// A constant with neither a type nor a value is reported as missing its
+	// value rather than its type.
+	missingValue := constant && (p.peekTokenIs(lexer.SEMI_COLON) ||
+		p.peekTokenIs(lexer.CLOSE_PARENTHESES) || p.peekTokenIs(lexer.EOF))
+
+	if !p.peekTokenIs(lexer.ASSIGNMENT) && !missingValue {
+		p.nextToken()
+		if spec.Type = p.parseType(); spec.Type == nil {
+			return nil
+		}
+	}
+
+	if p.peekTokenIs(lexer.ASSIGNMENT) {
+		p.nextToken()
+		p.nextToken()
+		spec.Value = p.parseExpression(LOWEST)
+	} else if constant {
+		p.errorf(p.peekToken.Start, "missing init expr for const declaration")
+		return nil
+	}
+	return spec
+}
+
+// parseShortVarDeclaration parses name := value, with the parser on name.
+func (p *Parser) parseShortVarDeclaration() ast.Statement {
+	name := &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+	p.nextToken()
+	stmt := &ast.ShortVarDeclaration{Token: p.currentToken, Name: name}
+
+	p.nextToken()
+	stmt.Value = p.parseExpression(LOWEST)
+
+	if p.peekTokenIs(lexer.SEMI_COLON) {
+		p.nextToken()
+	}
+	return stmt
+}
@@ -0,0 +1,164 @@
+package parser_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/parser"
+)
+
+func TestVarStatements(t *testing.T) {
+	tests := []struct {
+		input         string
+		expected      string
+		expectedName  string
+		expectedType  string
+		expectedValue string
+	}{
+		{"var age int = 1", "var age int = 1;", "age", "int", "1"},
+		{`var name = "Monkey"`, `var name = "Monkey";`, "name", "", `"Monkey"`},
+		{"var count int", "var count int;", "count", "int", ""},
+		{"var ok boolean", "var ok boolean;", "ok", "boolean", ""},
+		{"var grid [][]int", "var grid [][]int;", "grid", "[][]int", ""},
+		{"var xs []int = []int{1, 2}", "var xs []int = []int{1, 2};", "xs", "[]int", "[]int{1, 2}"},
+		{"var result = 10 * (10 / 2);", "var result = (10 * (10 / 2));", "result", "", "(10 * (10 / 2))"},
+	}
+
+	for _, tt := range tests {
+		stmt, ok := parseSingleStatement(t, tt.input).(*ast.VarStatement)
+		if !ok {
+			t.Fatalf("%q: statement is not *ast.VarStatement", tt.input)
+		}
+		if got := stmt.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+		if stmt.Grouped || len(stmt.Specs) != 1 {
+			t.Fatalf("%q: expected one ungrouped spec, got %d (grouped=%t)", tt.input, len(stmt.Specs), stmt.Grouped)
+		}
+		testValueSpec(t, stmt.Specs[0], tt.expectedName, tt.expectedType, tt.expectedValue)
+	}
+}
+
+func testValueSpec(t *testing.T, spec *ast.ValueSpec, name, typ, value string) {
+	t.Helper()
+	if spec.Name.Value != name {
+		t.Errorf("spec.Name wrong. expected=%q, got=%q", name, spec.Name.Value)
+	}
+	if typ == "" && spec.Type != nil || typ != "" && (spec.Type == nil || spec.Type.String() != typ) {
+		t.Errorf("%s: spec.Type wrong. expected=%q, got=%v", name, typ, spec.Type)
+	}
+	if value == "" && spec.Value != nil || value != "" && (spec.Value == nil || spec.Value.String() != value) {
+		t.Errorf("%s: spec.Value wrong. expected=%q, got=%v", name, value, spec.Value)
+	}
+}
+
+func TestConstStatements(t *testing.T) {
+	stmt, ok := parseSingleStatement(t, "const pi float = 3.14").(*ast.ConstStatement)
+	if !ok {
+		t.Fatalf("statement is not *ast.ConstStatement")
+	}
+	if got := stmt.String(); got != "const pi float = 3.14;" {
+		t.Errorf("stmt.String() wrong. got=%q", got)
+	}
+	testValueSpec(t, stmt.Specs[0], "pi", "float", "3.14")
+
+	stmt = parseSingleStatement(t, `const greeting = "habari"`).(*ast.ConstStatement)
+	testValueSpec(t, stmt.Specs[0], "greeting", "", `"habari"`)
+}
+
+func TestGroupedDeclarations(t *testing.T) {
+	input := `
+var (
+	a = 1
+	b string
+
+	c []int = [3];
+)
+const ( x int = 10; y = "why" )
+var ()
+`
+	p := parser.NewParser(lexer.Tokenize(input))
+	program := p.ParseProgram()
+	CheckParserErrors(t, p)
+
+	if len(program.Statements) != 3 {
+		t.Fatalf("program.Statements does not contain 3 statements. got=%d", len(program.Statements))
+	}
+
+	vars, ok := program.Statements[0].(*ast.VarStatement)
+	if !ok || !vars.Grouped || len(vars.Specs) != 3 {
+		t.Fatalf("statement 0 is not a grouped var with 3 specs. got=%s", program.Statements[0])
+	}
+	testValueSpec(t, vars.Specs[0], "a", "", "1")
+	testValueSpec(t, vars.Specs[1], "b", "string", "")
+	testValueSpec(t, vars.Specs[2], "c", "[]int", "[3]")
+	if got := vars.String(); got != "var (a = 1;b string;c []int = [3];)" {
+		t.Errorf("vars.String() wrong. got=%q", got)
+	}
+
+	consts, ok := program.Statements[1].(*ast.ConstStatement)
+	if !ok || !consts.Grouped || len(consts.Specs) != 2 {
+		t.Fatalf("statement 1 is not a grouped const with 2 specs. got=%s", program.Statements[1])
+	}
+	testValueSpec(t, consts.Specs[0], "x", "int", "10")
+	testValueSpec(t, consts.Specs[1], "y", "", `"why"`)
+
+	empty, ok := program.Statements[2].(*ast.VarStatement)
+	if !ok || !empty.Grouped || len(empty.Specs) != 0 {
+		t.Fatalf("statement 2 is not an empty grouped var. got=%s", program.Statements[2])
+	}
+}
+
+func TestShortVarDeclarations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x := 5", "x := 5;"},
+		{"array := []int{1, 2, 3}", "array := []int{1, 2, 3};"},
+		{"add := fn(a, b) { a + b };", "add := fn(a, b) { (a + b) };"},
+	}
+
+	for _, tt := range tests {
+		stmt, ok := parseSingleStatement(t, tt.input).(*ast.ShortVarDeclaration)
+		if !ok {
+			t.Fatalf("%q: statement is not *ast.ShortVarDeclaration", tt.input)
+		}
+		if got := stmt.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+
+	stmt := parseSingleStatement(t, "for i := 0; i < 3; i := i + 1 { }").(*ast.ForStatement)
+	if _, ok := stmt.Init.(*ast.ShortVarDeclaration); !ok {
+		t.Errorf("for init is not *ast.ShortVarDeclaration. got=%T", stmt.Init)
+	}
+}
+
+func TestDeclarationErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"var = 5", "1:5: expected next token to be IDENTIFIER, got ASSIGNMENT instead"},
+		{"var x", "1:6: expected type, got SEMI_COLON"},
+		{"var x 5", "1:7: expected type, got INT"},
+		{"const x", "1:8: missing init expr for const declaration"},
+		{"const x int", "1:12: missing init expr for const declaration"},
+		{"const (\n\tx = 1\n\ty\n)", "3:3: missing init expr for const declaration"},
+		{"var (\n\ta = 1 b = 2\n)", "2:8: expected next token to be SEMI_COLON or CLOSE_PARENTHESES, got IDENTIFIER instead"},
+		{"var (\n\ta = 1\n", "3:1: expected next token to be IDENTIFIER, got EOF instead"},
+		{"a[0] := 1", "1:1: non-name (a[0]) on left side of :="},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 || errors[0] != tt.expectedError {
+			t.Errorf("%q: expected error %q, got %q", tt.input, tt.expectedError, errors)
+		}
+	}
+}
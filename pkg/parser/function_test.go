@@ -0,0 +1,180 @@
+package parser_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/parser"
+)
+
+func parseSingleStatement(t *testing.T, input string) ast.Statement {
+	t.Helper()
+	p := parser.NewParser(lexer.Tokenize(input))
+	program := p.ParseProgram()
+	CheckParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain 1 statement. got=%d", len(program.Statements))
+	}
+	return program.Statements[0]
+}
+
+func testIdentifier(t *testing.T, exp ast.Expression, value string) bool {
+	t.Helper()
+	ident, ok := exp.(*ast.Identifier)
+	if !ok {
+		t.Errorf("exp not *ast.Identifier. got=%T", exp)
+		return false
+	}
+	if ident.Value != value {
+		t.Errorf("ident.Value not %s. got=%s", value, ident.Value)
+		return false
+	}
+	return true
+}
+
+func TestFunctionLiteralParsing(t *testing.T) {
+	stmt, ok := parseSingleStatement(t, "fn(x, y) { x + y; }").(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("statement is not ast.ExpressionStatement")
+	}
+
+	function, ok := stmt.Expression.(*ast.FunctionLiteral)
+	if !ok {
+		t.Fatalf("stmt.Expression is not ast.FunctionLiteral. got=%T", stmt.Expression)
+	}
+	if len(function.Parameters) != 2 {
+		t.Fatalf("function literal parameters wrong. want 2, got=%d", len(function.Parameters))
+	}
+	testIdentifier(t, function.Parameters[0], "x")
+	testIdentifier(t, function.Parameters[1], "y")
+
+	if len(function.Body.Statements) != 1 {
+		t.Fatalf("function.Body.Statements has not 1 statement. got=%d", len(function.Body.Statements))
+	}
+	if body := function.Body.Statements[0].String(); body != "(x + y)" {
+		t.Errorf("function body wrong. got=%q", body)
+	}
+}
+
+func TestFunctionParameterParsing(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedParams []string
+	}{
+		{"fn() {};", []string{}},
+		{"fn(x) {};", []string{"x"}},
+		{"fn(x, y, z) {};", []string{"x", "y", "z"}},
+		{"function(x, y,) {};", []string{"x", "y"}},
+		{"fn(\n\tx,\n\ty,\n) {\n}", []string{"x", "y"}},
+	}
+
+	for _, tt := range tests {
+		stmt := parseSingleStatement(t, tt.input).(*ast.ExpressionStatement)
+		function := stmt.Expression.(*ast.FunctionLiteral)
+
+		if len(function.Parameters) != len(tt.expectedParams) {
+			t.Errorf("%q: length parameters wrong. want %d, got=%d", tt.input, len(tt.expectedParams), len(function.Parameters))
+			continue
+		}
+		for i, ident := range tt.expectedParams {
+			testIdentifier(t, function.Parameters[i], ident)
+		}
+	}
+}
+
+func TestFunctionStatementParsing(t *testing.T) {
+	input := `fn add(x, y) {
+	return x + y
+}`
+	stmt, ok := parseSingleStatement(t, input).(*ast.FunctionStatement)
+	if !ok {
+		t.Fatalf("statement is not ast.FunctionStatement")
+	}
+	if stmt.Name.Value != "add" {
+		t.Errorf("function name wrong. got=%q", stmt.Name.Value)
+	}
+	if len(stmt.Function.Parameters) != 2 {
+		t.Fatalf("function parameters wrong. want 2, got=%d", len(stmt.Function.Parameters))
+	}
+	if got := stmt.String(); got != "fn add(x, y) { return (x + y); }" {
+		t.Errorf("stmt.String() wrong. got=%q", got)
+	}
+	if pos := stmt.Pos(); pos.Line != 1 || pos.Column != 1 {
+		t.Errorf("stmt.Pos() wrong. got=%s", pos)
+	}
+}
+
+func TestCallExpressionParsing(t *testing.T) {
+	stmt := parseSingleStatement(t, "add(1, 2 * 3, 4 + 5);").(*ast.ExpressionStatement)
+
+	exp, ok := stmt.Expression.(*ast.CallExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not ast.CallExpression. got=%T", stmt.Expression)
+	}
+	if !testIdentifier(t, exp.Function, "add") {
+		return
+	}
+	if len(exp.Arguments) != 3 {
+		t.Fatalf("wrong length of arguments. got=%d", len(exp.Arguments))
+	}
+	testIntegerLiteral(t, exp.Arguments[0], 1)
+	if got := exp.Arguments[1].String(); got != "(2 * 3)" {
+		t.Errorf("argument 1 wrong. got=%q", got)
+	}
+	if got := exp.Arguments[2].String(); got != "(4 + 5)" {
+		t.Errorf("argument 2 wrong. got=%q", got)
+	}
+}
+
+func TestCallExpressionArgumentParsing(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectedArgs []string
+	}{
+		{"add();", []string{}},
+		{"add(1);", []string{"1"}},
+		{"add(1, 2,);", []string{"1", "2"}},
+		{"add(fn(x) { x }, \"s\")", []string{"fn(x) { x }", `"s"`}},
+		{"add(\n\t1,\n\t2,\n)", []string{"1", "2"}},
+	}
+
+	for _, tt := range tests {
+		stmt := parseSingleStatement(t, tt.input).(*ast.ExpressionStatement)
+		exp := stmt.Expression.(*ast.CallExpression)
+
+		if len(exp.Arguments) != len(tt.expectedArgs) {
+			t.Errorf("%q: wrong number of arguments. want %d, got=%d", tt.input, len(tt.expectedArgs), len(exp.Arguments))
+			continue
+		}
+		for i, arg := range tt.expectedArgs {
+			if got := exp.Arguments[i].String(); got != arg {
+				t.Errorf("%q: argument %d wrong. want %q, got=%q", tt.input, i, arg, got)
+			}
+		}
+	}
+}
+
+func TestFunctionParsingErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"fn(x, 1) {}", "1:7: expected next token to be IDENTIFIER, got INT instead"},
+		{"fn(x) x", "1:8: expected next token to be OPEN_CURLY, got SEMI_COLON instead"},
+		{"fn(x) [1] {}", "1:7: expected type, got OPEN_BRACKET"},
+		{"add(1, 2", "1:9: expected next token to be COMMA or CLOSE_PARENTHESES, got SEMI_COLON instead"},
+		{"fn f() {\n\tx", "2:3: expected next token to be CLOSE_CURLY, got EOF instead"},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 || errors[0] != tt.expectedError {
+			t.Errorf("%q: expected error %q, got %q", tt.input, tt.expectedError, errors)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+)
+
+// parseMethodStatement parses a statement starting with fn (. That is either
+// a method declaration, fn (p Point) Name(params) { body }, or a function
+// literal used as an expression, as in fn(x) { x }(1); the two differ only
+// in the name after the first parameter list.
+func (p *Parser) parseMethodStatement() ast.Statement {
+	token := p.currentToken
+	lit := &ast.FunctionLiteral{Token: token}
+
+	p.nextToken()
+	lit.Parameters, lit.ParameterTypes = p.parseFunctionParameters()
+	if lit.Parameters == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(lexer.IDENTIFIER) {
+		p.nextToken()
+		if p.peekTokenIs(lexer.OPEN_PARENTHESES) {
+			return p.parseMethod(token, lit)
+		}
+		// The identifier is the result type of a function literal.
+		lit.Result = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+		if !p.checkParameterTypes(lit) || !p.parseFunctionBlock(lit) {
+			return nil
+		}
+	} else if !p.checkParameterTypes(lit) || !p.parseFunctionBody(lit) {
+		return nil
+	}
+
+	return p.finishExpressionStatement(token, p.parseInfixExpressions(lit, LOWEST))
+}
+
+// parseMethod parses the rest of a method declaration, with the parser on
+// its name. receiver holds the parameter list before the name, which must
+// declare exactly one receiver of type T or *T.
+func (p *Parser) parseMethod(token lexer.Token, receiver *ast.FunctionLiteral) ast.Statement {
+	stmt := &ast.MethodStatement{Token: token}
+
+	switch {
+	case len(receiver.Parameters) == 0:
+		p.errorf(token.Start, "method has no receiver")
+		return nil
+	case len(receiver.Parameters) > 1:
+		p.errorf(receiver.Parameters[1].Pos(), "method has multiple receivers")
+		return nil
+	case receiver.ParameterTypes == nil:
+		p.errorf(receiver.Parameters[0].Pos(), "missing type for receiver %s", receiver.Parameters[0])
+		return nil
+	}
+	stmt.Receiver = receiver.Parameters[0]
+	stmt.ReceiverType = receiver.ParameterTypes[0]
+
+	base := stmt.ReceiverType
+	if pointer, ok := base.(*ast.PointerType); ok {
+		base = pointer.Base
+	}
+	if _, ok := base.(*ast.Identifier); !ok {
+		p.errorf(stmt.ReceiverType.Pos(), "invalid receiver type %s", stmt.ReceiverType)
+		return nil
+	}
+
+	stmt.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	lit := &ast.FunctionLiteral{Token: token}
+	p.nextToken()
+	lit.Parameters, lit.ParameterTypes = p.parseFunctionParameters()
+	if lit.Parameters == nil || !p.checkParameterTypes(lit) || !p.parseFunctionBody(lit) {
+		return nil
+	}
+	stmt.Function = lit
+
+	if p.peekTokenIs(lexer.SEMI_COLON) {
+		p.nextToken()
+	}
+	return stmt
+}
@@ -0,0 +1,113 @@
+package parser_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/parser"
+)
+
+func TestMethodStatements(t *testing.T) {
+	tests := []struct {
+		input            string
+		expected         string
+		expectedReceiver string
+		expectedName     string
+	}{
+		{"fn (p Point) Dist() float { p.X }", "fn (p Point) Dist() float { p.X }", "Point", "Dist"},
+		{"fn (p *Point) Move(dx int) { p.X += dx }", "fn (p *Point) Move(dx int) { p.X += dx; }", "*Point", "Move"},
+		{"fn (c Circle) Scale(x, y float, n) Circle { c }", "fn (c Circle) Scale(x float, y float, n) Circle { c }", "Circle", "Scale"},
+		{"fn(p Point) String() []string { [] }", "fn (p Point) String() []string { [] }", "Point", "String"},
+	}
+
+	for _, tt := range tests {
+		stmt, ok := parseSingleStatement(t, tt.input).(*ast.MethodStatement)
+		if !ok {
+			t.Fatalf("%q: statement is not *ast.MethodStatement", tt.input)
+		}
+		if got := stmt.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+		if got := stmt.ReceiverType.String(); got != tt.expectedReceiver {
+			t.Errorf("%q: receiver type wrong. expected=%q, got=%q", tt.input, tt.expectedReceiver, got)
+		}
+		testIdentifier(t, stmt.Name, tt.expectedName)
+	}
+}
+
+func TestTypedFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn add(x int, y int) int { x + y }", "fn add(x int, y int) int { (x + y) }"},
+		{"fn add(x, y int) { x + y }", "fn add(x int, y int) { (x + y) }"},
+		{"fn f(xs []int, p Point) {}", "fn f(xs []int, p Point) { }"},
+		{"let f = fn(x int) int { x };", "let f = fn(x int) int { x };"},
+		// A function literal can still start a statement, with or without a
+		// result type.
+		{"fn(x) { x }(1)", "fn(x) { x }(1)"},
+		{"fn(x int) int { x }(1)", "fn(x int) int { x }(1)"},
+		{"fn(x) []int { [x] }(1)[0]", "(fn(x) []int { [x] }(1)[0])"},
+		{"fn() { 1 } == f", "(fn() { 1 } == f)"},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		program := p.ParseProgram()
+		CheckParserErrors(t, p)
+
+		if got := program.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestMethodSelectors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"abc".length()`, `"abc".length()`},
+		{"[1, 2].first()", "[1, 2].first()"},
+		{"true.and(false).or(x)", "true.and(false).or(x)"},
+		{"f := p.Dist", "f := p.Dist;"},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		program := p.ParseProgram()
+		CheckParserErrors(t, p)
+
+		if got := program.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestMethodErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"fn () Dist() {}", "1:1: method has no receiver"},
+		{"fn (p Point, q Point) Dist() {}", "1:14: method has multiple receivers"},
+		{"fn (p) Dist() {}", "1:5: missing type for receiver p"},
+		{"fn (p []Point) Dist() {}", "1:7: invalid receiver type []Point"},
+		{"fn (p Point) Move(q *Point) {}", "1:21: pointer type *Point is only allowed for a method receiver"},
+		{"fn f(q *Point) {}", "1:8: pointer type *Point is only allowed for a method receiver"},
+		{"fn (p Point) Dist(x int {}", "1:25: expected next token to be COMMA or CLOSE_PARENTHESES, got OPEN_CURLY instead"},
+		{"p.(1)", "1:3: expected next token to be IDENTIFIER, got OPEN_PARENTHESES instead"},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 || errors[0] != tt.expectedError {
+			t.Errorf("%q: expected error %q, got %q", tt.input, tt.expectedError, errors)
+		}
+	}
+}
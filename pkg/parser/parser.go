@@ -3,31 +3,60 @@ package parser
 import (
 	"fmt"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"kisumu/pkg/ast"
 	"kisumu/pkg/lexer"
 )
 
+// Operator precedences, from loosest to tightest binding. As in Go, | and ^
+// bind like + and -, and &, &^, << and >> like * and /.
 const (
 	_ int = iota
 	LOWEST
+	TERNARY     // c ? a : b
+	LOGICAL_OR  // ||
+	LOGICAL_AND // &&
 	EQUALS      // ==
 	LESSGREATER // > or <
+	RANGE       // 1..10
 	SUM         // +
 	PRODUCT     // *
 	PREFIX      // -X or !X
 	CALL        // myFunction(X)
+	INDEX       // array[index]
 )
 
 var precedence = map[lexer.TokenType]int{
-	lexer.EQUALS:     EQUALS,
-	lexer.NOT_EQUALS: EQUALS,
-	lexer.LESS:       LESSGREATER,
-	lexer.GREATER:    LESSGREATER,
-	lexer.PLUS:       SUM,
-	lexer.DASH:       SUM,
-	lexer.SLASH:      PRODUCT,
-	lexer.ASTERISK:   PRODUCT,
+	lexer.QUESTION:       TERNARY,
+	lexer.OR:             LOGICAL_OR,
+	lexer.AND:            LOGICAL_AND,
+	lexer.EQUALS:         EQUALS,
+	lexer.NOT_EQUALS:     EQUALS,
+	lexer.LESS:           LESSGREATER,
+	lexer.LESS_EQUAL:     LESSGREATER,
+	lexer.GREATER:        LESSGREATER,
+	lexer.GREATER_EQUALS: LESSGREATER,
+	lexer.INSTANCEOF:     LESSGREATER,
+	lexer.DOT_DOT:        RANGE,
+	lexer.DOT_DOT_LESS:   RANGE,
+	lexer.PLUS:           SUM,
+	lexer.DASH:           SUM,
+	lexer.PIPE:           SUM,
+	lexer.CARET:          SUM,
+	lexer.SLASH:          PRODUCT,
+	lexer.ASTERISK:       PRODUCT,
+	lexer.PERCENT:        PRODUCT,
+	lexer.AMPERSAND:      PRODUCT,
+	lexer.AND_NOT:        PRODUCT,
+	lexer.SHIFT_LEFT:     PRODUCT,
+	lexer.SHIFT_RIGHT:    PRODUCT,
+
+	lexer.OPEN_PARENTHESES: CALL,
+	lexer.OPEN_BRACKET:     INDEX,
+	lexer.DOT:              INDEX,
+	lexer.OPEN_CURLY:       INDEX,
 }
 
 type Parser struct {
@@ -35,8 +64,15 @@ type Parser struct {
 	currentToken  lexer.Token
 	peekToken     lexer.Token
 	errors        []string
+	errorLine     int  // line of the last recorded error
+	failures      int  // errors found, including those errorf did not record
+	tooMany       bool // the error limit was reached; parsing has stopped
+	depth         int  // number of unclosed { up to and including the current token
+	exprLev       int  // < 0 in the header of an if or loop, where { starts the body; > 0 inside brackets
 	prefixParseFn map[lexer.TokenType]prefixParseFn
 	infixParseFn  map[lexer.TokenType]infixParseFn
+
+	loops []string // labels of the enclosing loops, innermost last; "" for an unlabeled loop
 }
 
 type LetStatement struct {
@@ -57,11 +93,29 @@ func NewParser(l *lexer.Lexer) *Parser {
 	}
 	p.nextToken()
 	p.nextToken()
+	p.prefixParseFn = make(map[lexer.TokenType]prefixParseFn)
 	p.registerPrefix(lexer.BANG, p.parsePrefixExpression)
 	p.registerPrefix(lexer.DASH, p.parsePrefixExpression)
-	p.prefixParseFn = make(map[lexer.TokenType]prefixParseFn)
+	p.registerPrefix(lexer.CARET, p.parsePrefixExpression) // ^x bitwise complement
+	p.registerPrefix(lexer.TYPEOF, p.parsePrefixExpression)
 	p.registerPrefix(lexer.IDENTIFIER, p.parseIdentifier)
 	p.registerPrefix(lexer.INT, p.parseIntegerLiteral)
+	p.registerPrefix(lexer.FLOAT, p.parseFloatLiteral)
+	p.registerPrefix(lexer.IMAGINARY, p.parseImaginaryLiteral)
+	p.registerPrefix(lexer.STRING, p.parseStringLiteral)
+	p.registerPrefix(lexer.RUNE, p.parseRuneLiteral)
+	p.registerPrefix(lexer.OPEN_PARENTHESES, p.parseGroupedExpression)
+	p.registerPrefix(lexer.FN, p.parseFunctionLiteral)
+	p.registerPrefix(lexer.TRUE, p.parseBoolean)
+	p.registerPrefix(lexer.FALSE, p.parseBoolean)
+	p.registerPrefix(lexer.NULL, p.parseNullLiteral)
+	p.registerPrefix(lexer.IF, p.parseIfExpression)
+	p.registerPrefix(lexer.OPEN_BRACKET, p.parseArrayLiteral)
+	p.registerPrefix(lexer.OPEN_CURLY, p.parseHashLiteral)
+	p.registerPrefix(lexer.STRUCT_TYPE, p.parseStructTypeExpression)
+	p.registerPrefix(lexer.NEW, p.parseNewExpression)
+	p.registerPrefix(lexer.THIS, p.parseThisExpression)
+	p.registerPrefix(lexer.SUPER, p.parseSuperExpression)
 	p.infixParseFn = make(map[lexer.TokenType]infixParseFn)
 	p.registerInfix(lexer.PLUS, p.parseInfixExpression)
 	p.registerInfix(lexer.DASH, p.parseInfixExpression)
@@ -71,6 +125,25 @@ func NewParser(l *lexer.Lexer) *Parser {
 	p.registerInfix(lexer.NOT_EQUALS, p.parseInfixExpression)
 	p.registerInfix(lexer.LESS, p.parseInfixExpression)
 	p.registerInfix(lexer.GREATER, p.parseInfixExpression)
+	p.registerInfix(lexer.LESS_EQUAL, p.parseInfixExpression)
+	p.registerInfix(lexer.GREATER_EQUALS, p.parseInfixExpression)
+	p.registerInfix(lexer.PERCENT, p.parseInfixExpression)
+	p.registerInfix(lexer.AMPERSAND, p.parseInfixExpression)
+	p.registerInfix(lexer.PIPE, p.parseInfixExpression)
+	p.registerInfix(lexer.CARET, p.parseInfixExpression)
+	p.registerInfix(lexer.AND_NOT, p.parseInfixExpression)
+	p.registerInfix(lexer.SHIFT_LEFT, p.parseInfixExpression)
+	p.registerInfix(lexer.SHIFT_RIGHT, p.parseInfixExpression)
+	p.registerInfix(lexer.AND, p.parseInfixExpression)
+	p.registerInfix(lexer.OR, p.parseInfixExpression)
+	p.registerInfix(lexer.INSTANCEOF, p.parseInfixExpression)
+	p.registerInfix(lexer.QUESTION, p.parseTernaryExpression)
+	p.registerInfix(lexer.DOT_DOT, p.parseRangeExpression)
+	p.registerInfix(lexer.DOT_DOT_LESS, p.parseRangeExpression)
+	p.registerInfix(lexer.OPEN_PARENTHESES, p.parseCallExpression)
+	p.registerInfix(lexer.OPEN_BRACKET, p.parseIndexExpression)
+	p.registerInfix(lexer.DOT, p.parseSelectorExpression)
+	p.registerInfix(lexer.OPEN_CURLY, p.parseCompositeLiteral)
 
 	return p
 }
@@ -83,41 +156,210 @@ func (p *Parser) Errors() []string {
 	return p.errors
 }
 
-func (p *Parser) peekError(t lexer.TokenType) {
-	msg := fmt.Sprintf("expected next token to be %s, got %s instead", t, p.peekToken.Type)
-	p.errors = append(p.errors, msg)
+// maxErrors is the number of errors after which the parser gives up on a
+// file; past it, most errors are cascades of the earlier ones.
+const maxErrors = 10
+
+// errorf records an error at pos. Like the Go compiler, it keeps only the
+// first error on a line, since the rest usually follow from it; the others
+// still count in p.failures, so the statement they are in is discarded.
+func (p *Parser) errorf(pos lexer.Position, format string, args ...any) {
+	p.failures++
+	if p.tooMany || (len(p.errors) > 0 && pos.Line == p.errorLine) {
+		return
+	}
+	if len(p.errors) == maxErrors {
+		p.errors = append(p.errors, fmt.Sprintf("%s: too many errors", pos))
+		p.tooMany = true
+		return
+	}
+	p.errorLine = pos.Line
+	p.errors = append(p.errors, fmt.Sprintf("%s: ", pos)+fmt.Sprintf(format, args...))
+}
+
+// peekError records that the next token is not one of the expected types.
+func (p *Parser) peekError(expected ...lexer.TokenType) {
+	p.errorf(p.peekToken.Start, "expected next token to be %s, got %s instead", tokenList(expected), p.peekToken.Type)
+}
+
+// tokenList formats a set of expected token types as "A", "A or B" or
+// "one of A, B or C".
+func tokenList(types []lexer.TokenType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
+	}
+	switch len(names) {
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " or " + names[1]
+	}
+	return "one of " + strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
 }
 
 func (p *Parser) nextToken() {
 	p.currentToken = p.peekToken
 	p.peekToken = p.l.GetNextToken()
+
+	switch {
+	case p.currentTokenIs(lexer.OPEN_CURLY):
+		p.depth++
+	case p.currentTokenIs(lexer.CLOSE_CURLY) && p.depth > 0:
+		p.depth--
+	}
 }
 
 func (p *Parser) ParseProgram() *ast.Program {
 	program := &ast.Program{}
 	program.Statements = []ast.Statement{}
 
-	for p.currentToken.Type != lexer.EOF {
-		stmt := p.parseStatement()
-		program.Statements = append(program.Statements, stmt)
+	for !p.currentTokenIs(lexer.EOF) && !p.tooMany {
+		if p.currentTokenIs(lexer.SEMI_COLON) {
+			p.nextToken()
+			continue
+		}
+		if stmt := p.parseStatementOrRecover(); stmt != nil {
+			program.Statements = append(program.Statements, stmt)
+		}
 		p.nextToken()
 	}
 
+	// Problems found by the lexer usually explain the parser errors that
+	// follow them, so they are reported first.
+	if lexErrors := p.l.Errors(); len(lexErrors) != 0 {
+		errors := make([]string, 0, len(lexErrors)+len(p.errors))
+		for _, d := range lexErrors {
+			errors = append(errors, d.Error())
+		}
+		p.errors = append(errors, p.errors...)
+	}
+
 	return program
 }
 
+// parseStatementOrRecover parses a statement and checks that it is properly
+// terminated. If the statement has errors it is dropped, having skipped the
+// rest of it so that parsing can carry on with the next statement; if the
+// malformed statement ran into a keyword that starts the next one, that
+// statement is parsed and returned in its place, and otherwise it returns nil.
+func (p *Parser) parseStatementOrRecover() ast.Statement {
+	for {
+		start := p.currentToken.Start
+		depth := p.depth
+		failures := p.failures
+		stmt := p.parseStatement()
+		if stmt != nil && p.failures == failures && !p.currentTokenIs(lexer.SEMI_COLON) {
+			switch p.peekToken.Type {
+			case lexer.SEMI_COLON, lexer.CLOSE_CURLY, lexer.EOF:
+			default:
+				p.errorf(p.peekToken.Start, "unexpected %s at end of statement", p.peekToken.Type)
+			}
+		}
+		if stmt != nil && p.failures == failures {
+			return stmt
+		}
+		if !p.synchronize(depth, start) || p.tooMany {
+			return nil
+		}
+	}
+}
+
+// synchronize skips the rest of a malformed statement that started at start,
+// at brace depth depth. It stops on the statement's closing semicolon, before
+// a closing brace, the end of the file or a keyword that starts a statement,
+// or on the closing brace of the enclosing block if the statement was cut
+// short by it. Tokens in nested blocks are skipped whole. If the statement
+// itself ran into a keyword that starts a statement, synchronize stops on the
+// keyword and reports true, so that its statement can be parsed.
+func (p *Parser) synchronize(depth int, start lexer.Position) bool {
+	for p.depth >= depth {
+		if p.depth == depth {
+			if p.currentTokenIs(lexer.SEMI_COLON) {
+				return false
+			}
+			if startsStatement(p.currentToken.Type) && p.currentToken.Start != start {
+				return true
+			}
+			if p.peekTokenIs(lexer.CLOSE_CURLY) || startsStatement(p.peekToken.Type) {
+				return false
+			}
+		}
+		if p.peekTokenIs(lexer.EOF) {
+			return false
+		}
+		p.nextToken()
+	}
+	return false
+}
+
+// startsStatement reports whether a token of type t begins a statement
+// wherever it appears.
+func startsStatement(t lexer.TokenType) bool {
+	switch t {
+	case lexer.LET, lexer.VAR, lexer.CONST, lexer.TYPE, lexer.RETURN, lexer.CLASS, lexer.FN,
+		lexer.WHILE, lexer.FOR, lexer.FOREACH, lexer.BREAK, lexer.CONTINUE:
+		return true
+	}
+	return false
+}
+
 func (p *Parser) parseStatement() ast.Statement {
 	switch p.currentToken.Type {
 	case lexer.LET:
 		return p.parseLetStatement()
+	case lexer.VAR:
+		return p.parseVarStatement()
+	case lexer.CONST:
+		return p.parseConstStatement()
 	case lexer.RETURN:
 		return p.parseReturnStatement()
+	case lexer.TYPE:
+		return p.parseTypeStatement()
+	case lexer.CLASS:
+		return p.parseClassStatement()
+	case lexer.FN:
+		switch {
+		case p.peekTokenIs(lexer.IDENTIFIER):
+			return p.parseFunctionStatement()
+		case p.peekTokenIs(lexer.OPEN_PARENTHESES):
+			return p.parseMethodStatement()
+		}
+		return p.parseExpressionStatement()
+	case lexer.WHILE:
+		return p.parseWhileStatement("")
+	case lexer.FOR:
+		return p.parseForStatement("")
+	case lexer.FOREACH:
+		return p.parseForeachStatement("")
+	case lexer.BREAK, lexer.CONTINUE:
+		return p.parseBranchStatement()
+	case lexer.IDENTIFIER:
+		if p.peekTokenIs(lexer.COLON) {
+			return p.parseLabeledStatement()
+		}
+		if p.peekTokenIs(lexer.COLON_EQUALS) {
+			return p.parseShortVarDeclaration()
+		}
+		return p.parseExpressionStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
 }
 
-func (p *Parser) parseLetStatement() *ast.LetStatement {
+// parseSimpleStatement parses the statements allowed in the header of a for
+// loop: let statements, short variable declarations and expressions.
+func (p *Parser) parseSimpleStatement() ast.Statement {
+	if p.currentTokenIs(lexer.LET) {
+		return p.parseLetStatement()
+	}
+	if p.currentTokenIs(lexer.IDENTIFIER) && p.peekTokenIs(lexer.COLON_EQUALS) {
+		return p.parseShortVarDeclaration()
+	}
+	return p.parseExpressionStatement()
+}
+
+func (p *Parser) parseLetStatement() ast.Statement {
 	stmt := &ast.LetStatement{Token: p.currentToken}
 
 	if !p.expectPeek(lexer.IDENTIFIER) {
@@ -130,9 +372,9 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 	}
 
 	p.nextToken()
-	stmt.Value = p.parseExpression(0)
+	stmt.Value = p.parseExpression(LOWEST)
 
-	for !p.currentTokenIs(lexer.SEMI_COLON) {
+	if p.peekTokenIs(lexer.SEMI_COLON) {
 		p.nextToken()
 	}
 
@@ -157,11 +399,20 @@ func (p *Parser) expectPeek(t lexer.TokenType) bool {
 	}
 }
 
-func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
+func (p *Parser) parseReturnStatement() ast.Statement {
 	stmt := &ast.ReturnStatement{Token: p.currentToken}
+
+	// A bare return ends at the semicolon, explicit or inserted at the
+	// end of the line.
+	if p.peekTokenIs(lexer.SEMI_COLON) || p.peekTokenIs(lexer.EOF) {
+		p.nextToken()
+		return stmt
+	}
 	p.nextToken()
 
-	for !p.currentTokenIs(lexer.SEMI_COLON) {
+	stmt.ReturnValue = p.parseExpression(LOWEST)
+
+	if p.peekTokenIs(lexer.SEMI_COLON) {
 		p.nextToken()
 	}
 	return stmt
@@ -175,10 +426,29 @@ func (p *Parser) registerInfix(tokenType lexer.TokenType, fn infixParseFn) {
 	p.infixParseFn[tokenType] = fn
 }
 
-func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
-	stmt := &ast.ExpressionStatement{Token: p.currentToken}
+// parseExpressionStatement parses an expression used as a statement, or an
+// assignment if the expression is followed by =.
+func (p *Parser) parseExpressionStatement() ast.Statement {
+	token := p.currentToken
+	return p.finishExpressionStatement(token, p.parseExpression(LOWEST))
+}
 
-	stmt.Expression = p.parseExpression(LOWEST)
+// finishExpressionStatement completes a statement that starts with the
+// expression exp, with the parser on its last token.
+func (p *Parser) finishExpressionStatement(token lexer.Token, exp ast.Expression) ast.Statement {
+	stmt := &ast.ExpressionStatement{Token: token, Expression: exp}
+
+	switch p.peekToken.Type {
+	case lexer.ASSIGNMENT, lexer.COMMA,
+		lexer.PLUS_EQUALS, lexer.MINUS_EQUALS, lexer.STAR_EQUALS, lexer.SLASH_EQUALS:
+		return p.parseAssignStatement(stmt.Expression)
+	case lexer.PLUS_PLUS, lexer.MINUS_MINUS:
+		return p.parseIncDecStatement(stmt.Expression)
+	}
+	if p.peekTokenIs(lexer.COLON_EQUALS) && stmt.Expression != nil {
+		p.errorf(stmt.Expression.Pos(), "non-name %s on left side of :=", stmt.Expression.String())
+		return nil
+	}
 
 	if p.peekTokenIs(lexer.SEMI_COLON) {
 		p.nextToken()
@@ -190,10 +460,18 @@ func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 func (p *Parser) parseExpression(precedence int) ast.Expression {
 	prefix := p.prefixParseFn[p.currentToken.Type]
 	if prefix == nil {
+		p.noPrefixParseFnError(p.currentToken.Type)
 		return nil
 	}
-	leftExp := prefix()
-	for !p.peekTokenIs(lexer.SEMI_COLON) && precedence < p.peekPrecedence() {
+	return p.parseInfixExpressions(prefix(), precedence)
+}
+
+// parseInfixExpressions extends leftExp with the operators following it that
+// bind more tightly than precedence.
+func (p *Parser) parseInfixExpressions(leftExp ast.Expression, precedence int) ast.Expression {
+	// An operand that failed to parse ends the expression; its error has
+	// already been recorded.
+	for leftExp != nil && !p.peekTokenIs(lexer.SEMI_COLON) && precedence < p.peekPrecedence() {
 		infix := p.infixParseFn[p.peekToken.Type]
 		if infix == nil {
 			return leftExp
@@ -207,6 +485,11 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 }
 
 func (p *Parser) peekPrecedence() int {
+	// In the header of an if or loop, { starts the body: if p == Point{} {
+	// needs parentheses, as in Go.
+	if p.peekTokenIs(lexer.OPEN_CURLY) && p.exprLev < 0 {
+		return LOWEST
+	}
 	if p, ok := precedence[p.peekToken.Type]; ok {
 		return p
 	}
@@ -224,17 +507,61 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	lit := &ast.IntegerLiteral{Token: p.currentToken}
 	value, err := strconv.ParseInt(p.currentToken.Literal, 0, 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as integer", p.currentToken.Literal)
-		p.errors = append(p.errors, msg)
+		p.errorf(p.currentToken.Start, "could not parse %q as integer", p.currentToken.Literal)
 		return nil
 	}
 	lit.Value = value
 	return lit
 }
 
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{Token: p.currentToken}
+	value, err := strconv.ParseFloat(p.currentToken.Literal, 64)
+	if err != nil {
+		p.errorf(p.currentToken.Start, "could not parse %q as float", p.currentToken.Literal)
+		return nil
+	}
+	lit.Value = value
+	return lit
+}
+
+func (p *Parser) parseImaginaryLiteral() ast.Expression {
+	lit := &ast.ImaginaryLiteral{Token: p.currentToken}
+	mantissa := strings.TrimSuffix(p.currentToken.Literal, "i")
+
+	var value float64
+	var err error
+	if len(mantissa) > 1 && mantissa[0] == '0' && strings.ContainsAny(mantissa[1:2], "xXoObB") {
+		var i int64
+		i, err = strconv.ParseInt(mantissa, 0, 64)
+		value = float64(i)
+	} else {
+		// Like Go, 0123i is decimal: a leading zero does not mean octal here.
+		value, err = strconv.ParseFloat(mantissa, 64)
+	}
+	if err != nil {
+		p.errorf(p.currentToken.Start, "could not parse %q as imaginary number", p.currentToken.Literal)
+		return nil
+	}
+	lit.Value = complex(0, value)
+	return lit
+}
+
+func (p *Parser) parseStringLiteral() ast.Expression {
+	return &ast.StringLiteral{Token: p.currentToken, Value: p.currentToken.Literal}
+}
+
+func (p *Parser) parseRuneLiteral() ast.Expression {
+	// The lexer has already reported malformed rune literals; an empty one
+	// decodes to utf8.RuneError here.
+	value, _ := utf8.DecodeRuneInString(p.currentToken.Literal)
+	return &ast.RuneLiteral{Token: p.currentToken, Value: value}
+}
+
+// noPrefixParseFnError reports a token of type t where an expression should
+// start.
 func (p *Parser) noPrefixParseFnError(t lexer.TokenType) {
-	msg := fmt.Sprintf("no prefix parse function for %s found", t)
-	p.errors = append(p.errors, msg)
+	p.errorf(p.currentToken.Start, "expected expression, got %s", t)
 }
 
 func (p *Parser) parsePrefixExpression() ast.Expression {
@@ -250,7 +577,7 @@ func (p *Parser) parsePrefixExpression() ast.Expression {
 
 func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	expression := &ast.InfixExpression{
-		Token:    p.currentToken.Type,
+		Token:    p.currentToken,
 		Operator: p.currentToken.Literal,
 		Left:     left,
 	}
@@ -261,3 +588,244 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 
 	return expression
 }
+
+func (p *Parser) parseGroupedExpression() ast.Expression {
+	p.exprLev++
+	defer func() { p.exprLev-- }()
+
+	p.nextToken()
+
+	exp := p.parseExpression(LOWEST)
+	if !p.expectPeek(lexer.CLOSE_PARENTHESES) {
+		return nil
+	}
+	return exp
+}
+
+// parseBlockStatement parses the statements between the current { and the
+// matching }, on which it leaves the parser.
+func (p *Parser) parseBlockStatement() *ast.BlockStatement {
+	block := &ast.BlockStatement{Token: p.currentToken}
+	block.Statements = []ast.Statement{}
+
+	exprLev := p.exprLev
+	p.exprLev = 0
+	defer func() { p.exprLev = exprLev }()
+
+	p.nextToken()
+	depth := p.depth
+	for !p.currentTokenIs(lexer.CLOSE_CURLY) && !p.tooMany {
+		if p.currentTokenIs(lexer.EOF) {
+			p.errorf(p.currentToken.Start, "expected next token to be %s, got %s instead", lexer.CLOSE_CURLY, lexer.EOF)
+			return block
+		}
+		if !p.currentTokenIs(lexer.SEMI_COLON) {
+			if stmt := p.parseStatementOrRecover(); stmt != nil {
+				block.Statements = append(block.Statements, stmt)
+			}
+			if p.depth < depth {
+				break // a malformed statement ran into the closing brace
+			}
+		}
+		p.nextToken()
+	}
+	return block
+}
+
+func (p *Parser) parseFunctionLiteral() ast.Expression {
+	lit := &ast.FunctionLiteral{Token: p.currentToken}
+
+	if !p.expectPeek(lexer.OPEN_PARENTHESES) {
+		return nil
+	}
+	lit.Parameters, lit.ParameterTypes = p.parseFunctionParameters()
+	if lit.Parameters == nil || !p.checkParameterTypes(lit) {
+		return nil
+	}
+
+	if !p.parseFunctionBody(lit) {
+		return nil
+	}
+	return lit
+}
+
+// parseFunctionBody parses the result type, if there is one, and the body
+// of a function, with the parser on the ) closing its parameters.
+func (p *Parser) parseFunctionBody(lit *ast.FunctionLiteral) bool {
+	if !p.peekTokenIs(lexer.OPEN_CURLY) {
+		p.nextToken()
+		if lit.Result = p.parseType(); lit.Result == nil {
+			return false
+		}
+	}
+	return p.parseFunctionBlock(lit)
+}
+
+// parseFunctionBlock parses the { body } of a function after its signature.
+func (p *Parser) parseFunctionBlock(lit *ast.FunctionLiteral) bool {
+	// break and continue cannot reach loops outside the function.
+	loops := p.loops
+	p.loops = nil
+	defer func() { p.loops = loops }()
+
+	if !p.expectPeek(lexer.OPEN_CURLY) {
+		return false
+	}
+	lit.Body = p.parseBlockStatement()
+	return true
+}
+
+// parseFunctionStatement parses a named function declaration,
+// fn name(params) { body }, which binds the function to name.
+func (p *Parser) parseFunctionStatement() ast.Statement {
+	stmt := &ast.FunctionStatement{Token: p.currentToken}
+
+	p.nextToken()
+	stmt.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	lit, ok := p.parseFunctionLiteral().(*ast.FunctionLiteral)
+	if !ok {
+		return nil
+	}
+	lit.Token = stmt.Token
+	stmt.Function = lit
+
+	if p.peekTokenIs(lexer.SEMI_COLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+// parseFunctionParameters parses the parameters after the current ( up to
+// the closing ): identifiers, each optionally followed by a type. As in Go,
+// an identifier without a type takes the type of the next parameter, so
+// x, y int declares two ints. The types are nil if no parameter has one. It
+// returns nil identifiers on a malformed list.
+func (p *Parser) parseFunctionParameters() ([]*ast.Identifier, []ast.Expression) {
+	identifiers := []*ast.Identifier{}
+	var types []ast.Expression
+
+	if p.peekTokenIs(lexer.CLOSE_PARENTHESES) {
+		p.nextToken()
+		return identifiers, nil
+	}
+
+	typed := false
+	for {
+		if !p.expectPeek(lexer.IDENTIFIER) {
+			return nil, nil
+		}
+		identifiers = append(identifiers, &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal})
+
+		var typ ast.Expression
+		if !p.peekTokenIs(lexer.COMMA) && !p.peekTokenIs(lexer.CLOSE_PARENTHESES) {
+			p.nextToken()
+			if typ = p.parseParameterType(); typ == nil {
+				return nil, nil
+			}
+			typed = true
+		}
+		types = append(types, typ)
+
+		if !p.peekTokenIs(lexer.COMMA) {
+			break
+		}
+		p.nextToken()
+		if p.peekTokenIs(lexer.CLOSE_PARENTHESES) {
+			break // trailing comma
+		}
+	}
+
+	if !p.peekTokenIs(lexer.CLOSE_PARENTHESES) {
+		p.peekError(lexer.COMMA, lexer.CLOSE_PARENTHESES)
+		return nil, nil
+	}
+	p.nextToken()
+
+	if !typed {
+		return identifiers, nil
+	}
+	for i := len(types) - 2; i >= 0; i-- {
+		if types[i] == nil {
+			types[i] = types[i+1]
+		}
+	}
+	return identifiers, types
+}
+
+// parseParameterType parses the type of a parameter, which for a method
+// receiver may be a pointer type *T.
+func (p *Parser) parseParameterType() ast.Expression {
+	if !p.currentTokenIs(lexer.ASTERISK) {
+		return p.parseType()
+	}
+	pointer := &ast.PointerType{Token: p.currentToken}
+	p.nextToken()
+	if pointer.Base = p.parseType(); pointer.Base == nil {
+		return nil
+	}
+	return pointer
+}
+
+// checkParameterTypes reports an error if a parameter of lit, which is not
+// a method receiver, has a pointer type.
+func (p *Parser) checkParameterTypes(lit *ast.FunctionLiteral) bool {
+	for _, typ := range lit.ParameterTypes {
+		if pointer, ok := typ.(*ast.PointerType); ok {
+			p.errorf(pointer.Pos(), "pointer type %s is only allowed for a method receiver", pointer)
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
+	exp := &ast.CallExpression{Token: p.currentToken, Function: function}
+	exp.Arguments = p.parseExpressionList(lexer.CLOSE_PARENTHESES)
+	return exp
+}
+
+// parseSelectorExpression parses x.name. The name may be a keyword, so that
+// methods such as and and or can be selected.
+func (p *Parser) parseSelectorExpression(x ast.Expression) ast.Expression {
+	exp := &ast.SelectorExpression{Token: p.currentToken, X: x}
+	if !p.peekTokenIs(lexer.IDENTIFIER) && lexer.LookupIdentifier(p.peekToken.Literal) != p.peekToken.Type {
+		p.peekError(lexer.IDENTIFIER)
+		return nil
+	}
+	p.nextToken()
+	exp.Sel = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+	return exp
+}
+
+// parseExpressionList parses comma-separated expressions after the current
+// token up to end, allowing a trailing comma.
+func (p *Parser) parseExpressionList(end lexer.TokenType) []ast.Expression {
+	list := []ast.Expression{}
+
+	p.exprLev++
+	defer func() { p.exprLev-- }()
+
+	if p.peekTokenIs(end) {
+		p.nextToken()
+		return list
+	}
+
+	p.nextToken()
+	list = append(list, p.parseExpression(LOWEST))
+	for p.peekTokenIs(lexer.COMMA) {
+		p.nextToken()
+		if p.peekTokenIs(end) {
+			break // trailing comma
+		}
+		p.nextToken()
+		list = append(list, p.parseExpression(LOWEST))
+	}
+
+	if !p.peekTokenIs(end) {
+		p.peekError(lexer.COMMA, end)
+		return nil
+	}
+	p.nextToken()
+	return list
+}
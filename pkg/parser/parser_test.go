@@ -249,7 +249,7 @@ func TestParsingInfixExpressions(t *testing.T) {
 		CheckParserErrors(t, p)
 
 		if len(program.Statements) != 1 {
-			t.Fatalf("program.Statements does not contain %d statements. Got %d\n", l, len(program.Statements))
+			t.Fatalf("program.Statements does not contain %d statements. Got %d\n", 1, len(program.Statements))
 		}
 
 		stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
@@ -267,7 +267,7 @@ func TestParsingInfixExpressions(t *testing.T) {
 		}
 
 		if exp.Operator != tt.operator {
-			t.Fatalf("exp.Operator is not '%d'. Got %d", tt.operator, exp.Operator)
+			t.Fatalf("exp.Operator is not '%s'. Got %s", tt.operator, exp.Operator)
 		}
 
 		if !testIntegerLiteral(t, exp.Right, tt.rightValue) {
@@ -333,6 +333,98 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"3 + 4 * 5 == 3 * 1 + 4 * 5",
 			"((3 + (4 * 5)) == ((3 * 1) + (4 * 5)))",
 		},
+		{
+			"(5 + 5) * 2",
+			"((5 + 5) * 2)",
+		},
+		{
+			"-(5 + 5)",
+			"(-(5 + 5))",
+		},
+		{
+			"a + add(b * c) + d",
+			"((a + add((b * c))) + d)",
+		},
+		{
+			"add(a, b, 1, 2 * 3, 4 + 5, add(6, 7 * 8))",
+			"add(a, b, 1, (2 * 3), (4 + 5), add(6, (7 * 8)))",
+		},
+		{
+			"add(a + b + c * d / f + g)",
+			"add((((a + b) + ((c * d) / f)) + g))",
+		},
+		{
+			"adder(1)(2) * 3",
+			"(adder(1)(2) * 3)",
+		},
+		{
+			"a <= b == c >= d",
+			"((a <= b) == (c >= d))",
+		},
+		{
+			"a || b && c",
+			"(a || (b && c))",
+		},
+		{
+			"a && b || c && d",
+			"((a && b) || (c && d))",
+		},
+		{
+			"a == b && c != d",
+			"((a == b) && (c != d))",
+		},
+		{
+			"a or b and c",
+			"(a or (b and c))",
+		},
+		{
+			"!a && b",
+			"((!a) && b)",
+		},
+		{
+			"a + b % c",
+			"(a + (b % c))",
+		},
+		{
+			"a | b & c",
+			"(a | (b & c))",
+		},
+		{
+			"a ^ b << 2 + c",
+			"((a ^ (b << 2)) + c)",
+		},
+		{
+			"a &^ b >> c * d",
+			"(((a &^ b) >> c) * d)",
+		},
+		{
+			"a & b == c",
+			"((a & b) == c)",
+		},
+		{
+			"^a | -b",
+			"((^a) | (-b))",
+		},
+		{
+			"a ? b : c",
+			"(a ? b : c)",
+		},
+		{
+			"a || b ? c + 1 : d * 2",
+			"((a || b) ? (c + 1) : (d * 2))",
+		},
+		{
+			"a ? b : c ? d : e",
+			"(a ? b : (c ? d : e))",
+		},
+		{
+			"a ? b ? c : d : e",
+			"(a ? (b ? c : d) : e)",
+		},
+		{
+			"f(a ? 1 : 2, b)",
+			"f((a ? 1 : 2), b)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -348,3 +440,62 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 		}
 	}
 }
+
+func TestParserErrorPositions(t *testing.T) {
+	input := "let x = 5;\nlet = 10;\nlet y 3;"
+
+	l := lexer.TokenizeFile("file.ksm", input)
+	p := parser.NewParser(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) == 0 {
+		t.Fatalf("expected parser errors, got none")
+	}
+	if errors[0] != "file.ksm:2:5: expected next token to be IDENTIFIER, got ASSIGNMENT instead" {
+		t.Errorf("wrong first error. got=%q", errors[0])
+	}
+}
+
+func TestNodePositions(t *testing.T) {
+	input := "let x = 5;\n  x + 10;"
+
+	l := lexer.Tokenize(input)
+	p := parser.NewParser(l)
+	program := p.ParseProgram()
+	CheckParserErrors(t, p)
+
+	letStmt := program.Statements[0].(*ast.LetStatement)
+	if pos := letStmt.Value.Pos(); pos.Line != 1 || pos.Column != 9 {
+		t.Errorf("let value position wrong. got=%s", pos)
+	}
+
+	stmt := program.Statements[1].(*ast.ExpressionStatement)
+	infix := stmt.Expression.(*ast.InfixExpression)
+	if pos := infix.Pos(); pos.Line != 2 || pos.Column != 3 {
+		t.Errorf("infix position wrong. got=%s", pos)
+	}
+	if pos := infix.Right.Pos(); pos.Line != 2 || pos.Column != 7 {
+		t.Errorf("infix right position wrong. got=%s", pos)
+	}
+}
+
+func TestNewlineTerminatedStatements(t *testing.T) {
+	withSemicolons := "let x = 5;\nlet y = x * 2 + 1;\nreturn y;\n"
+	withNewlines := "let x = 5\nlet y = x *\n\t2 + 1\nreturn y"
+
+	parse := func(input string) string {
+		l := lexer.Tokenize(input)
+		p := parser.NewParser(l)
+		program := p.ParseProgram()
+		CheckParserErrors(t, p)
+		if len(program.Statements) != 3 {
+			t.Fatalf("expected 3 statements for %q, got %d", input, len(program.Statements))
+		}
+		return program.String()
+	}
+
+	if a, b := parse(withSemicolons), parse(withNewlines); a != b {
+		t.Errorf("programs differ:\n%s\n%s", a, b)
+	}
+}
@@ -207,33 +207,16 @@ func TestSlashToken(t *testing.T) {
 }
 
 func TestParseAsteriskToken(t *testing.T) {
+	// * is only an infix operator; there are no pointers to dereference.
 	input := "*5;"
 
 	l := lexer.Tokenize(input)
 	p := parser.NewParser(l)
-	program := p.ParseProgram()
-	CheckParserErrors(t, p)
-
-	if len(program.Statements) != 1 {
-		t.Fatalf("program has not enough statements. got=%d", len(program.Statements))
-	}
-
-	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
-	if !ok {
-		t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T", program.Statements[0])
-	}
-
-	exp, ok := stmt.Expression.(*ast.PrefixExpression)
-	if !ok {
-		t.Fatalf("stmt is not ast.PrefixExpression. got=%T", stmt.Expression)
-	}
-
-	if exp.Operator != "*" {
-		t.Fatalf("exp.Operator is not '*'. got=%s", exp.Operator)
-	}
+	p.ParseProgram()
 
-	if !testIntegerLiteral(t, exp.Right, 5) {
-		return
+	errors := p.Errors()
+	if len(errors) != 1 || errors[0] != "1:1: expected expression, got ASTERISK" {
+		t.Fatalf("expected an error for prefix *, got %v", errors)
 	}
 }
 
@@ -351,3 +334,115 @@ func TestParsingInfixExpressionsWithLessGreater(t *testing.T) {
 		}
 	}
 }
+
+func TestStringLiteralExpression(t *testing.T) {
+	input := `"hello world";`
+
+	l := lexer.Tokenize(input)
+	p := parser.NewParser(l)
+	program := p.ParseProgram()
+	CheckParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	literal, ok := stmt.Expression.(*ast.StringLiteral)
+	if !ok {
+		t.Fatalf("exp not *ast.StringLiteral. got=%T", stmt.Expression)
+	}
+
+	if literal.Value != "hello world" {
+		t.Errorf("literal.Value not %q. got=%q", "hello world", literal.Value)
+	}
+}
+
+func TestUnterminatedStringIsReported(t *testing.T) {
+	input := `let s = "hello`
+
+	l := lexer.Tokenize(input)
+	p := parser.NewParser(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) == 0 || errors[0] != "1:9: unterminated string literal" {
+		t.Fatalf("expected unterminated string error first, got %v", errors)
+	}
+}
+
+func TestNumericLiteralExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"0x1F;", int64(31)},
+		{"0o17;", int64(15)},
+		{"0b1010;", int64(10)},
+		{"1_000_000;", int64(1000000)},
+		{"3.14;", 3.14},
+		{"1e9;", 1e9},
+		{"1_000.5;", 1000.5},
+		{"2.5i;", complex(0, 2.5)},
+		{"0x10i;", complex(0, 16)},
+	}
+
+	for _, tt := range tests {
+		l := lexer.Tokenize(tt.input)
+		p := parser.NewParser(l)
+		program := p.ParseProgram()
+		CheckParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		switch expected := tt.expected.(type) {
+		case int64:
+			testIntegerLiteralValue(t, stmt.Expression, expected)
+		case float64:
+			literal, ok := stmt.Expression.(*ast.FloatLiteral)
+			if !ok {
+				t.Errorf("exp not *ast.FloatLiteral. got=%T", stmt.Expression)
+				continue
+			}
+			if literal.Value != expected {
+				t.Errorf("literal.Value not %g. got=%g", expected, literal.Value)
+			}
+		case complex128:
+			literal, ok := stmt.Expression.(*ast.ImaginaryLiteral)
+			if !ok {
+				t.Errorf("exp not *ast.ImaginaryLiteral. got=%T", stmt.Expression)
+				continue
+			}
+			if literal.Value != expected {
+				t.Errorf("literal.Value not %g. got=%g", expected, literal.Value)
+			}
+		}
+	}
+}
+
+func testIntegerLiteralValue(t *testing.T, exp ast.Expression, value int64) {
+	literal, ok := exp.(*ast.IntegerLiteral)
+	if !ok {
+		t.Errorf("exp not *ast.IntegerLiteral. got=%T", exp)
+		return
+	}
+	if literal.Value != value {
+		t.Errorf("literal.Value not %d. got=%d", value, literal.Value)
+	}
+}
+
+func TestRuneLiteralExpression(t *testing.T) {
+	input := `'é';`
+
+	l := lexer.Tokenize(input)
+	p := parser.NewParser(l)
+	program := p.ParseProgram()
+	CheckParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	literal, ok := stmt.Expression.(*ast.RuneLiteral)
+	if !ok {
+		t.Fatalf("exp not *ast.RuneLiteral. got=%T", stmt.Expression)
+	}
+	if literal.Value != 'é' {
+		t.Errorf("literal.Value not %q. got=%q", 'é', literal.Value)
+	}
+	if literal.String() != `'é'` {
+		t.Errorf("literal.String() wrong. got=%s", literal.String())
+	}
+}
@@ -0,0 +1,197 @@
+package parser_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/parser"
+)
+
+func TestParserRecovery(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedErrors []string
+		expected       string
+	}{
+		{
+			"let = 5\nlet y 3\nlet z = 1\nz",
+			[]string{
+				"1:5: expected next token to be IDENTIFIER, got ASSIGNMENT instead",
+				"2:7: expected next token to be ASSIGNMENT, got INT instead",
+			},
+			"let z = 1;z",
+		},
+		{
+			"let x = 5 let y = 6",
+			[]string{"1:11: unexpected LET at end of statement"},
+			"let y = 6;",
+		},
+		{
+			"}\nlet a = 1",
+			[]string{"1:1: expected expression, got CLOSE_CURLY"},
+			"let a = 1;",
+		},
+		{
+			"[1, 2\nlet a = 1",
+			[]string{"1:6: expected next token to be COMMA or CLOSE_BRACKET, got SEMI_COLON instead"},
+			"let a = 1;",
+		},
+		{
+			"let a = {1: fn() { x } 2: 3}\nlet b = 2",
+			[]string{"1:24: expected next token to be COMMA or CLOSE_CURLY, got INT instead"},
+			"let b = 2;",
+		},
+		{
+			// Only the first error on a line is reported.
+			"let = ) + )\nx",
+			[]string{"1:5: expected next token to be IDENTIFIER, got ASSIGNMENT instead"},
+			"x",
+		},
+		{
+			// A statement that runs into the next one does not swallow it.
+			"let x = \nlet y = 5 +\nlet = 3",
+			[]string{
+				"2:1: expected expression, got LET",
+				"3:1: expected expression, got LET",
+			},
+			"",
+		},
+		{
+			"let x = \nlet y = 5\ny",
+			[]string{"2:1: expected expression, got LET"},
+			"let y = 5;y",
+		},
+		{
+			"if x { let a = \nreturn 1 }\nx",
+			[]string{"2:1: expected expression, got RETURN"},
+			"x",
+		},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		program := p.ParseProgram()
+
+		if !slices.Equal(p.Errors(), tt.expectedErrors) {
+			t.Errorf("%q: expected errors %q, got %q", tt.input, tt.expectedErrors, p.Errors())
+		}
+		if actual := program.String(); actual != tt.expected {
+			t.Errorf("%q: expected program %q, got %q", tt.input, tt.expected, actual)
+		}
+	}
+}
+
+func TestBlockRecovery(t *testing.T) {
+	input := `fn f() {
+	let x =
+}
+if x {
+	let = 1
+	y
+} else {
+	1 +
+}
+let z = 3`
+
+	p := parser.NewParser(lexer.Tokenize(input))
+	program := p.ParseProgram()
+
+	expectedErrors := []string{
+		"3:1: expected expression, got CLOSE_CURLY",
+		"5:6: expected next token to be IDENTIFIER, got ASSIGNMENT instead",
+		"9:1: expected expression, got CLOSE_CURLY",
+	}
+	if !slices.Equal(p.Errors(), expectedErrors) {
+		t.Errorf("expected errors %q, got %q", expectedErrors, p.Errors())
+	}
+	if actual := program.String(); actual != "let z = 3;" {
+		t.Errorf("expected program %q, got %q", "let z = 3;", actual)
+	}
+}
+
+func TestNoNilStatements(t *testing.T) {
+	inputs := []string{
+		"let = 5",
+		"var = 5",
+		"const x",
+		"return )",
+		"fn f( {}",
+		"x := )",
+		"while x { let = 1 }",
+		"fn() { var x int = }",
+		// Only the first error on a line is reported, but every statement
+		// with an error must still be dropped.
+		"a = 1; a = ; b = )",
+		"+= ; X .. ",
+		"let a = ; let b = )",
+		"x := ; y := ]; z := 1",
+		"f(; g(1, ; h()",
+		"while x { a = ; b = ) }",
+	}
+
+	for _, input := range inputs {
+		p := parser.NewParser(lexer.Tokenize(input))
+		program := p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("%q: expected parser errors, got none", input)
+		}
+		for i, stmt := range program.Statements {
+			if stmt == nil {
+				t.Errorf("%q: statement %d is nil", input, i)
+			}
+		}
+		_ = program.String() // must not panic on a half-built statement
+	}
+}
+
+func TestSameLineErrorsDropStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let a = ; let b = )", ""},
+		{"a = 1; a = ; b = )", "a = 1;"},
+		{"let a = ; let b = 2", "let b = 2;"},
+		{"x := ; y := ]; z := 1", "z := 1;"},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		program := p.ParseProgram()
+
+		if len(p.Errors()) != 1 {
+			t.Errorf("%q: expected 1 error, got %q", tt.input, p.Errors())
+		}
+		if got := program.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestTooManyErrors(t *testing.T) {
+	var input strings.Builder
+	for i := 0; i < 20; i++ {
+		input.WriteString("let = 1\n")
+	}
+
+	p := parser.NewParser(lexer.Tokenize(input.String()))
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) != 11 {
+		t.Fatalf("expected 10 errors and a limit notice, got %d: %q", len(errors), errors)
+	}
+	for i, msg := range errors[:10] {
+		expected := fmt.Sprintf("%d:5: expected next token to be IDENTIFIER, got ASSIGNMENT instead", i+1)
+		if msg != expected {
+			t.Errorf("errors[%d]: expected %q, got %q", i, expected, msg)
+		}
+	}
+	if errors[10] != "11:5: too many errors" {
+		t.Errorf("expected limit notice, got %q", errors[10])
+	}
+}
@@ -0,0 +1,165 @@
+package parser
+
+import (
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+)
+
+// parseTypeStatement parses type Name struct { ... }.
+func (p *Parser) parseTypeStatement() ast.Statement {
+	stmt := &ast.TypeStatement{Token: p.currentToken}
+
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	if !p.expectPeek(lexer.STRUCT_TYPE) {
+		return nil
+	}
+	if stmt.Type = p.parseStructType(); stmt.Type == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(lexer.SEMI_COLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+// parseStructTypeExpression parses an anonymous struct type, as in
+// struct { X int }{X: 1}.
+func (p *Parser) parseStructTypeExpression() ast.Expression {
+	if structType := p.parseStructType(); structType != nil {
+		return structType
+	}
+	return nil
+}
+
+// parseStructType parses struct { ... }, with the parser on struct. Fields
+// are separated by semicolons or newlines.
+func (p *Parser) parseStructType() *ast.StructType {
+	structType := &ast.StructType{Token: p.currentToken, Fields: []*ast.Field{}}
+	if !p.expectPeek(lexer.OPEN_CURLY) {
+		return nil
+	}
+
+	names := make(map[string]bool)
+	for {
+		for p.peekTokenIs(lexer.SEMI_COLON) {
+			p.nextToken()
+		}
+		if p.peekTokenIs(lexer.CLOSE_CURLY) {
+			p.nextToken()
+			return structType
+		}
+
+		fields := p.parseFieldDecl()
+		if fields == nil {
+			return nil
+		}
+		for _, field := range fields {
+			if names[field.FieldName()] {
+				p.errorf(field.Pos(), "duplicate field %s", field.FieldName())
+				return nil
+			}
+			names[field.FieldName()] = true
+		}
+		structType.Fields = append(structType.Fields, fields...)
+
+		if !p.peekTokenIs(lexer.SEMI_COLON) && !p.peekTokenIs(lexer.CLOSE_CURLY) {
+			p.peekError(lexer.SEMI_COLON, lexer.CLOSE_CURLY)
+			return nil
+		}
+	}
+}
+
+// parseFieldDecl parses one line of a struct type starting from the token
+// before it: X int, X, Y int for several fields of one type, or a type name
+// alone for an embedded field.
+func (p *Parser) parseFieldDecl() []*ast.Field {
+	if !p.expectPeek(lexer.IDENTIFIER) {
+		return nil
+	}
+	name := &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	if p.peekTokenIs(lexer.SEMI_COLON) || p.peekTokenIs(lexer.CLOSE_CURLY) {
+		return []*ast.Field{{Type: name}}
+	}
+
+	names := []*ast.Identifier{name}
+	for p.peekTokenIs(lexer.COMMA) {
+		p.nextToken()
+		if !p.expectPeek(lexer.IDENTIFIER) {
+			return nil
+		}
+		names = append(names, &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal})
+	}
+
+	p.nextToken()
+	typ := p.parseType()
+	if typ == nil {
+		return nil
+	}
+	fields := make([]*ast.Field, len(names))
+	for i, name := range names {
+		fields[i] = &ast.Field{Name: name, Type: typ}
+	}
+	return fields
+}
+
+// parseCompositeLiteral parses the {...} of Type{...}, with the parser on
+// the {. The elements are either all Field: value or all plain values.
+func (p *Parser) parseCompositeLiteral(typ ast.Expression) ast.Expression {
+	lit := &ast.CompositeLiteral{Token: p.currentToken, Type: typ}
+
+	switch typ.(type) {
+	case *ast.Identifier, *ast.StructType:
+	default:
+		p.errorf(typ.Pos(), "invalid composite literal type %s", typ.String())
+		return nil
+	}
+
+	p.exprLev++
+	defer func() { p.exprLev-- }()
+
+	lit.Elements = []ast.Expression{}
+	keyed := 0
+	for !p.peekTokenIs(lexer.CLOSE_CURLY) {
+		p.nextToken()
+		element := p.parseExpression(LOWEST)
+		if element == nil {
+			return nil
+		}
+
+		if p.peekTokenIs(lexer.COLON) {
+			if _, ok := element.(*ast.Identifier); !ok {
+				p.errorf(element.Pos(), "invalid field name %s in struct literal", element.String())
+				return nil
+			}
+			p.nextToken()
+			kv := &ast.KeyValueExpression{Token: p.currentToken, Key: element}
+			p.nextToken()
+			if kv.Value = p.parseExpression(LOWEST); kv.Value == nil {
+				return nil
+			}
+			element = kv
+			keyed++
+		}
+		lit.Elements = append(lit.Elements, element)
+
+		if keyed != 0 && keyed != len(lit.Elements) {
+			p.errorf(element.Pos(), "mixture of field:value and value elements in struct literal")
+			return nil
+		}
+
+		if p.peekTokenIs(lexer.COMMA) {
+			p.nextToken()
+		} else if !p.peekTokenIs(lexer.CLOSE_CURLY) {
+			p.peekError(lexer.COMMA, lexer.CLOSE_CURLY)
+			return nil
+		}
+	}
+	p.nextToken()
+	return lit
+}
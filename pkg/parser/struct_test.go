@@ -0,0 +1,151 @@
+package parser_test
+
+import (
+	"testing"
+
+	"kisumu/pkg/ast"
+	"kisumu/pkg/lexer"
+	"kisumu/pkg/parser"
+)
+
+func TestTypeStatements(t *testing.T) {
+	tests := []struct {
+		input          string
+		expected       string
+		expectedFields []string
+	}{
+		{"type Empty struct {}", "type Empty struct {};", []string{}},
+		{"type Point struct { X int; Y int }", "type Point struct { X int; Y int };", []string{"X int", "Y int"}},
+		{"type Point struct {\n\tX, Y int\n}", "type Point struct { X int; Y int };", []string{"X int", "Y int"}},
+		{
+			"type Circle struct {\n\tPoint\n\tR float\n\tTags []string\n}",
+			"type Circle struct { Point; R float; Tags []string };",
+			[]string{"Point", "R float", "Tags []string"},
+		},
+		{
+			"type Line struct { A struct { X int }; B Point }",
+			"type Line struct { A struct { X int }; B Point };",
+			[]string{"A struct { X int }", "B Point"},
+		},
+	}
+
+	for _, tt := range tests {
+		stmt, ok := parseSingleStatement(t, tt.input).(*ast.TypeStatement)
+		if !ok {
+			t.Fatalf("%q: statement is not *ast.TypeStatement", tt.input)
+		}
+		if got := stmt.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+		if len(stmt.Type.Fields) != len(tt.expectedFields) {
+			t.Fatalf("%q: expected %d fields, got %d", tt.input, len(tt.expectedFields), len(stmt.Type.Fields))
+		}
+		for i, field := range stmt.Type.Fields {
+			if field.String() != tt.expectedFields[i] {
+				t.Errorf("%q: field %d: expected %q, got %q", tt.input, i, tt.expectedFields[i], field.String())
+			}
+		}
+	}
+}
+
+func TestEmbeddedField(t *testing.T) {
+	stmt := parseSingleStatement(t, "type Circle struct { Point; R float }").(*ast.TypeStatement)
+
+	embedded := stmt.Type.Fields[0]
+	if embedded.Name != nil {
+		t.Errorf("embedded field has a name: %s", embedded.Name)
+	}
+	if embedded.FieldName() != "Point" {
+		t.Errorf("embedded field name wrong. expected=%q, got=%q", "Point", embedded.FieldName())
+	}
+	testIdentifier(t, embedded.Type, "Point")
+}
+
+func TestCompositeLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Point{}", "Point{}"},
+		{"Point{X: 1, Y: 2}", "Point{X: 1, Y: 2}"},
+		{"Point{1, 2}", "Point{1, 2}"},
+		{"Point{\n\tX: 1,\n\tY: 2,\n}", "Point{X: 1, Y: 2}"},
+		{"Line{A: Point{1, 2}, B: Point{X: a + b}}", "Line{A: Point{1, 2}, B: Point{X: (a + b)}}"},
+		{"struct { Name string }{Name: n}", "struct { Name string }{Name: n}"},
+		{"Point{X: 1}.X", "Point{X: 1}.X"},
+		{"[Point{1, 2}]", "[Point{1, 2}]"},
+		{"p == Point{}", "(p == Point{})"},
+	}
+
+	for _, tt := range tests {
+		stmt, ok := parseSingleStatement(t, tt.input).(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("%q: statement is not *ast.ExpressionStatement", tt.input)
+		}
+		if got := stmt.Expression.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestCompositeLiteralsInControlClauses(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		// In a header, { starts the body, so a literal needs parentheses.
+		{"if p == (Point{}) { 1 }", "if (p == Point{}) { 1 }"},
+		{"if p { Point{1, 2} }", "if p { Point{1, 2} }"},
+		{"while f(Point{}) { x }", "while f(Point{}) { x }"},
+		{"foreach p in [Point{}] { p }", "foreach p in [Point{}] { p }"},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		program := p.ParseProgram()
+		CheckParserErrors(t, p)
+
+		if got := program.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestFieldSelectors(t *testing.T) {
+	stmt := parseSingleStatement(t, "c.Center.X = 5").(*ast.AssignStatement)
+
+	selector, ok := stmt.Targets[0].(*ast.SelectorExpression)
+	if !ok {
+		t.Fatalf("target is not *ast.SelectorExpression. got=%T", stmt.Targets[0])
+	}
+	testIdentifier(t, selector.Sel, "X")
+	if selector.X.String() != "c.Center" {
+		t.Errorf("selector.X wrong. expected=%q, got=%q", "c.Center", selector.X.String())
+	}
+}
+
+func TestStructErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"type Point { X int }", "1:12: expected next token to be STRUCT_TYPE, got OPEN_CURLY instead"},
+		{"type Point struct { X int Y int }", "1:27: expected next token to be SEMI_COLON or CLOSE_CURLY, got IDENTIFIER instead"},
+		{"type Point struct { X int; X float }", "1:28: duplicate field X"},
+		{"type Point struct { X 1 }", "1:23: expected type, got INT"},
+		{"Point{X: 1, 2}", "1:13: mixture of field:value and value elements in struct literal"},
+		{"Point{a.b: 1}", "1:7: invalid field name a.b in struct literal"},
+		{"f(){X: 1}", "1:1: invalid composite literal type f()"},
+		{"Point{X: 1 Y: 2}", "1:12: expected next token to be COMMA or CLOSE_CURLY, got IDENTIFIER instead"},
+	}
+
+	for _, tt := range tests {
+		p := parser.NewParser(lexer.Tokenize(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 || errors[0] != tt.expectedError {
+			t.Errorf("%q: expected error %q, got %q", tt.input, tt.expectedError, errors)
+		}
+	}
+}